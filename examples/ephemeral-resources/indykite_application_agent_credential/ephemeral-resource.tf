@@ -0,0 +1,20 @@
+# Credential living only for the duration of one Terraform run,
+# it is deleted when Terraform closes the ephemeral resource and never stored in the state.
+ephemeral "indykite_application_agent_credential" "ci" {
+  app_agent_id = indykite_application_agent.my_agent.id
+  display_name = "CI pipeline credential"
+  expire_in    = "30m"
+}
+
+# Ephemeral values can be passed only to other ephemeral contexts,
+# like provider configuration or write-only attributes.
+provider "example" {
+  agent_credentials = ephemeral.indykite_application_agent_credential.ci.agent_config
+}
+
+# Key pair generated locally, so the private key never crosses the API
+ephemeral "indykite_application_agent_credential" "local_key" {
+  app_agent_id      = indykite_application_agent.my_agent.id
+  expire_in         = "1h"
+  generate_key_pair = true
+}
@@ -0,0 +1,25 @@
+locals {
+  policy = jsonencode({
+    meta     = { policyVersion = "1.0-indykite" }
+    subject  = { type = "Person" }
+    actions  = ["CAN_DRIVE"]
+    resource = { type = "Car" }
+    condition = {
+      cypher = "MATCH (subject:Person)-[:OWNS]->(resource:Car)"
+    }
+  })
+}
+
+resource "indykite_authorization_policy" "drive_car" {
+  name     = "policy-drive-car"
+  location = indykite_application_space.my_space.id
+  status   = "active"
+  json     = local.policy
+
+  lifecycle {
+    precondition {
+      condition     = provider::indykite::validate_policy(local.policy)
+      error_message = "Policy does not match the KBAC policy schema."
+    }
+  }
+}
@@ -11,5 +11,21 @@ terraform {
   }
 }
 
-# Provider for now does not support any parameters and all is set within service account credential file.
+# Without arguments, credentials are read from INDYKITE_SERVICE_ACCOUNT_CREDENTIALS
+# or INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE environment variables.
 provider "indykite" {}
+
+# Aliased provider for another organization in a different region.
+provider "indykite" {
+  alias            = "us"
+  credentials_file = "${path.module}/us-service-account.json"
+  region           = "us"
+  request_timeout  = "90s"
+
+  credential_expiry_warning = "720h"
+
+  retry {
+    max_attempts = 6
+    max_backoff  = "1m"
+  }
+}
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_application.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_application.example 'my-application?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_application.example app-space/my-application
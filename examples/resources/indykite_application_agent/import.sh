@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_application_agent.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_application_agent.example 'my-agent?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_application_agent.example app-space/my-agent
@@ -0,0 +1,2 @@
+# Import by GID
+terraform import indykite_application_agent_credential.example gid:AAAAAmluZHlraURlgAABDwAAAAA
@@ -38,6 +38,25 @@ resource "indykite_application_agent_credential" "credential_for_new_agent" {
   expire_time  = "2028-06-30T12:00:00Z"
 }
 
+# Example 6: Credential rotated 7 days before it expires, keeping the previous one
+# available through previous_agent_config for the default overlap of 24 hours
+resource "indykite_application_agent_credential" "rotated_credential" {
+  app_agent_id = indykite_application_agent.my_agent.id
+  display_name = "Rotated Credential"
+  rotation {
+    validity      = "720h"
+    rotate_before = "168h"
+  }
+}
+
+# Example 7: Key pair generated locally by the provider, only the public key is uploaded.
+# Agent configuration with the private key is written to the file and never stored in the state.
+resource "indykite_application_agent_credential" "local_key_pair" {
+  app_agent_id     = indykite_application_agent.my_agent.id
+  display_name     = "Locally Generated Key"
+  private_key_file = "${path.module}/agent-credential.json"
+}
+
 # Note: The credential's kid (key ID) and other details are automatically
 # generated and can be referenced in outputs:
 #
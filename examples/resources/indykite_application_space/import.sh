@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_application_space.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Organization given by GID
+terraform import indykite_application_space.example 'my-app-space?location=gid:AAAAAWluZHlraURlgAAAAAAAAA8'
+
+# Import by name within Organization given by name
+terraform import indykite_application_space.example my-organization/my-app-space
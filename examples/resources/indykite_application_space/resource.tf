@@ -84,3 +84,18 @@ resource "indykite_application_space" "appspace_with_composite_db" {
     alias_mapping     = "global=testdb1&east=testdb2&west=testdb3"
   }
 }
+
+# Example 7: Write-only database password, never stored in the plan or state
+# (requires Terraform 1.11+). Increment password_wo_version to rotate it.
+resource "indykite_application_space" "appspace_with_db_wo" {
+  customer_id = data.indykite_customer.my_customer.id
+  name        = "appspace-with-db-wo"
+  region      = "us-east1"
+  db_connection {
+    url                 = "postgresql://db.example.com:5432/indykite"
+    username            = "dbuser"
+    password_wo         = var.db_password
+    password_wo_version = 1
+    name                = "indykite"
+  }
+}
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_authorization_policy.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_authorization_policy.example 'my-policy?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_authorization_policy.example app-space/my-policy
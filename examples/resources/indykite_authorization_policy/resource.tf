@@ -126,38 +126,57 @@ resource "indykite_authorization_policy" "policy_for_ciq" {
     "allowed_reads" : {
       "nodes" : ["ln.property.value", "ln.property.transferrable", "ln.external_id"],
       "relationships" : ["r1"]
-    }
+    },
     "allowed_upserts" : { # omitted if empty
       "nodes" : {         # omitted if empty
-        "existing_nodes" : [
-          "<string>"
-        ],
-        "node_labels" : [
-          "<string>"
-        ]
+        "existing_nodes" : ["vehicle"],
+        "node_labels" : ["PaymentMethod"]
       },
       "relationships" : { # omitted if empty
-        "existing_relationships" : [
-          "<string>"
-        ],
+        "existing_relationships" : ["r2"],
         "relationship_types" : [ # omitted if empty
           {
-            "type" : "<string>",
-            "source_node_label" : "<string>",
-            "target_node_label" : "<string>"
+            "type" : "PAYS_WITH",
+            "source_node_label" : "Person",
+            "target_node_label" : "PaymentMethod"
           }
         ]
       }
     },
     "allowed_deletes" : { # omitted if empty
-      "nodes" : ["<string>"],
-      "relationships" : ["<string>"]
-    },
+      "nodes" : ["ln"],
+      "relationships" : ["r3"]
+    }
   })
   location = indykite_application_space.appspace.id
   status   = "active"
 }
 
+# Example 6: Structured policy block instead of JSON
+resource "indykite_authorization_policy" "structured_policy" {
+  name     = "structured-policy"
+  location = indykite_application_space.my_space.id
+  status   = "active"
+
+  policy {
+    subject {
+      type = "Person"
+    }
+    actions = ["CAN_DRIVE", "CAN_PERFORM_SERVICE"]
+    resource {
+      type = "Vehicle"
+    }
+    condition {
+      cypher = "MATCH (subject:Person)-[:OWNS]->(resource:Vehicle)"
+      filter {
+        attribute = "subject.property.email"
+        operator  = "="
+        value     = "$email"
+      }
+    }
+  }
+}
+
 # Note: The location parameter accepts an Application Space ID.
 # You can use either a hardcoded GID or a reference to an application_space resource.
 # The policy will automatically populate app_space_id and customer_id as computed fields.
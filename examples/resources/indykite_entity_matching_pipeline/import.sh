@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_entity_matching_pipeline.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_entity_matching_pipeline.example 'my-pipeline?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_entity_matching_pipeline.example app-space/my-pipeline
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_event_sink.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_event_sink.example 'my-event-sink?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_event_sink.example app-space/my-event-sink
@@ -10,6 +10,7 @@
 # - var.azure_bus_connection_string
 # - var.kafka_audit_password
 # - var.kafka_capture_password
+# - var.pubsub_credentials_json
 
 # Original example - comprehensive event sink with all provider types (still valid)
 resource "indykite_event_sink" "create-event" {
@@ -254,6 +255,28 @@ resource "indykite_event_sink" "multi_route" {
   }
 }
 
+# Example - secrets kept out of the state with write-only attributes (Terraform 1.11+)
+# Increase the version to send a rotated secret to IndyKite.
+resource "indykite_event_sink" "write-only-secrets" {
+  location = "AppSpaceID"
+  name     = "write-only-secrets"
+  providers {
+    provider_name = "pubsub"
+    pubsub {
+      project_id                  = "my-gcp-project"
+      topic_name                  = "indykite-events"
+      credentials_json_wo         = var.pubsub_credentials_json
+      credentials_json_wo_version = 1
+    }
+  }
+  routes {
+    provider_id = "pubsub"
+    keys_values_filter {
+      event_type = "indykite.audit.*"
+    }
+  }
+}
+
 # Note: The location parameter accepts an Application Space ID.
 # You must define at least one provider (kafka, azure_event_grid, or azure_service_bus).
 # You must define at least one route that references a provider_id.
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_external_data_resolver.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_external_data_resolver.example 'my-resolver?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_external_data_resolver.example app-space/my-resolver
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_knowledge_query.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_knowledge_query.example 'my-query?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_knowledge_query.example app-space/my-query
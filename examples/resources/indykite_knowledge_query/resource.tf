@@ -41,7 +41,7 @@ resource "indykite_knowledge_query" "minimal_query" {
   status    = "active"
   policy_id = indykite_authorization_policy.policy_for_ciq.id
   query = jsonencode({
-    "nodes" : ["Person.property.email"]
+    "nodes" : ["subject.property.email"]
   })
 }
 
@@ -54,8 +54,8 @@ resource "indykite_knowledge_query" "query_with_ref" {
   status       = "active"
   policy_id    = indykite_authorization_policy.policy_for_ciq.id
   query = jsonencode({
-    "nodes" : ["User.property.name", "User.property.email"],
-    "relationships" : ["BELONGS_TO"]
+    "nodes" : ["subject.property.name", "subject.property.email"],
+    "relationships" : ["r1"]
   })
 }
 
@@ -66,11 +66,11 @@ resource "indykite_knowledge_query" "query_with_policy" {
   description  = "Knowledge query with authorization policy"
   location     = indykite_application_space.my_space.id
   status       = "active"
-  policy_id    = indykite_authorization_policy.my_policy.id
+  policy_id    = indykite_authorization_policy.policy_for_ciq.id
   query = jsonencode({
-    "nodes" : ["Document.property.title", "Document.property.content"],
-    "relationships" : ["OWNS", "CAN_READ"],
-    "filter" : { "attribute" : "Document.property.status", "operator" : "=", "value" : "published" }
+    "nodes" : ["vehicle.property.model", "ln.property.value"],
+    "relationships" : ["r2", "r3"],
+    "filter" : { "attribute" : "vehicle.property.status", "operator" : "=", "value" : "registered" }
   })
 }
 
@@ -81,16 +81,16 @@ resource "indykite_knowledge_query" "complex_query" {
   description  = "Query with complex filtering and relationships"
   location     = indykite_application_space.my_space.id
   status       = "active"
-  policy_id    = indykite_authorization_policy.my_policy.id
+  policy_id    = indykite_authorization_policy.policy_for_ciq.id
   query = jsonencode({
     "nodes" : [
-      "Person.property.name",
-      "Person.property.email",
-      "Organization.property.name"
+      "subject.property.name",
+      "subject.property.email",
+      "contract.property.number"
     ],
-    "relationships" : ["WORKS_FOR", "MANAGES"],
+    "relationships" : ["r1", "r2"],
     "filter" : {
-      "attribute" : "Person.property.status",
+      "attribute" : "subject.property.status",
       "operator" : "=",
       "value" : "$status"
     }
@@ -106,11 +106,13 @@ resource "indykite_knowledge_query" "inactive_query" {
   status       = "inactive"
   policy_id    = indykite_authorization_policy.policy_for_ciq.id
   query = jsonencode({
-    "nodes" : ["Asset.property.value"]
+    "nodes" : ["ln.property.value"]
   })
 }
 
 # Note: The location parameter accepts an Application Space ID.
 # status can be either "active" or "inactive".
-# policy_id is optional and references an authorization policy.
+# policy_id references an authorization policy. Nodes, relationships and filter attributes of the query
+# must refer to variables of the policy cypher, like `ln` or `r1` above, which is checked at plan time.
+# The check reads the policy from the server, set skip_policy_check when both change in the same run.
 # The query will automatically populate app_space_id and customer_id as computed fields.
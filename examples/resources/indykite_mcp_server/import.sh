@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_mcp_server.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_mcp_server.example 'my-mcp-server?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_mcp_server.example app-space/my-mcp-server
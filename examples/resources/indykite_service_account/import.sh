@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_service_account.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Organization given by GID
+terraform import indykite_service_account.example 'my-service-account?location=gid:AAAAAWluZHlraURlgAAAAAAAAA8'
+
+# Import by name within Organization given by name
+terraform import indykite_service_account.example my-organization/my-service-account
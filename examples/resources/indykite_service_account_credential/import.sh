@@ -0,0 +1,2 @@
+# Import by GID
+terraform import indykite_service_account_credential.example gid:AAAAAmluZHlraURlgAABDwAAAAA
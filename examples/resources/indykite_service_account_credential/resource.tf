@@ -72,7 +72,26 @@ resource "local_file" "service_account_config" {
   file_permission = "0600"
 }
 
-# Example 11: Using credential output in other resources
+# Example 11: Rotated credential, new one is created 30 days before the current one expires,
+# and the previous one stays valid for 48 hours, so consumers can switch to the new one.
+resource "indykite_service_account_credential" "rotated_credential" {
+  service_account_id = indykite_service_account.my_service_account.id
+  display_name       = "Rotated Credential"
+  rotation {
+    validity      = "2160h" # 90 days
+    rotate_before = "720h"  # 30 days
+    overlap       = "48h"
+  }
+}
+
+# Example 12: Credential rotated on demand by changing rotation_trigger
+resource "indykite_service_account_credential" "manually_rotated_credential" {
+  service_account_id = indykite_service_account.my_service_account.id
+  display_name       = "Manually Rotated Credential"
+  rotation_trigger   = "2026-10"
+}
+
+# Example 13: Using credential output in other resources
 output "service_account_credential_kid" {
   description = "Key identifier of the service account credential"
   value       = indykite_service_account_credential.credential_with_ref.kid
@@ -87,4 +106,5 @@ output "service_account_credential_id" {
 # It contains the complete JSON configuration needed to authenticate with IndyKite APIs.
 # customer_id is a computed field automatically populated from the service account.
 # expire_time must be in RFC3339 format (e.g., "2025-12-31T23:59:59Z").
-# The credential cannot be updated after creation - all fields are ForceNew.
+# Changes of service_account_id, display_name or expire_time replace the credential,
+# use rotation or rotation_trigger to rotate it without downtime.
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_token_introspect.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_token_introspect.example 'my-introspect?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_token_introspect.example app-space/my-introspect
@@ -0,0 +1,8 @@
+# Import by GID
+terraform import indykite_trust_score_profile.example gid:AAAAAmluZHlraURlgAABDwAAAAA
+
+# Import by name within Application Space given by GID
+terraform import indykite_trust_score_profile.example 'my-profile?location=gid:AAAAAmluZHlraURlgAABDwAAAAA'
+
+# Import by name within Application Space given by name
+terraform import indykite_trust_score_profile.example app-space/my-profile
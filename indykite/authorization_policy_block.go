@@ -0,0 +1,280 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	authzPolicyKey       = "policy"
+	policyVersionKey     = "version"
+	policySubjectKey     = "subject"
+	policyActionsKey     = "actions"
+	policyResourceKey    = "resource"
+	policyConditionKey   = "condition"
+	policyTypeKey        = "type"
+	policyCypherKey      = "cypher"
+	policyFilterAppKey   = "app"
+	policyAttributeKey   = "attribute"
+	policyOperatorKey    = "operator"
+	defaultPolicyVersion = "1.0-indykite"
+)
+
+var (
+	// policyNodeTypeRegex matches node labels of the Identity Knowledge Graph.
+	policyNodeTypeRegex   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	policyFilterOperators = []string{
+		"=", "<>", "<", "<=", ">", ">=", "IN", "CONTAINS", "STARTS WITH", "ENDS WITH",
+	}
+)
+
+// kbacPolicy is the JSON document of KBAC policy, which can be expressed by the policy block.
+type kbacPolicy struct {
+	Condition *kbacCondition `json:"condition,omitempty"`
+	Meta      kbacMeta       `json:"meta"`
+	Subject   kbacNode       `json:"subject"`
+	Resource  kbacNode       `json:"resource"`
+	Actions   []string       `json:"actions"`
+}
+
+type kbacMeta struct {
+	PolicyVersion string `json:"policyVersion"`
+}
+
+type kbacNode struct {
+	Type string `json:"type"`
+}
+
+type kbacCondition struct {
+	Cypher string       `json:"cypher"`
+	Filter []kbacFilter `json:"filter,omitempty"`
+}
+
+type kbacFilter struct {
+	App       string `json:"app,omitempty"`
+	Attribute string `json:"attribute"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+}
+
+func policyNodeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				policyTypeKey: {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringMatch(policyNodeTypeRegex,
+						"must be a node label starting with a letter and containing only letters, digits and '_'"),
+					Description: "Node label in the Identity Knowledge Graph, like `Person`.",
+				},
+			},
+		},
+	}
+}
+
+// authzPolicyBlockSchema returns the structured alternative of the json attribute.
+func authzPolicyBlockSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{authzJSONConfigKey, authzPolicyKey},
+		Description: "Structured KBAC policy, which compiles to the JSON stored in `json`. " +
+			"Use `json` for policies, which cannot be expressed by this block.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				policyVersionKey: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      defaultPolicyVersion,
+					ValidateFunc: validation.StringInSlice([]string{defaultPolicyVersion}, false),
+					Description:  "Version of the policy format, stored as `meta.policyVersion`.",
+				},
+				policySubjectKey:  policyNodeSchema("Node the authorization decision is made for."),
+				policyResourceKey: policyNodeSchema("Node the subject wants to act on."),
+				policyActionsKey: {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotWhiteSpace,
+					},
+					Description: "Actions the subject can perform on the resource, like `CAN_DRIVE`.",
+				},
+				policyConditionKey: {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Condition, which must be met in the Identity Knowledge Graph.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							policyCypherKey: {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+								Description:  "Cypher pattern matching `subject` and `resource` nodes.",
+							},
+							filterKey: {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "Filters applied to nodes matched by `cypher`.",
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										policyFilterAppKey: {
+											Type:        schema.TypeString,
+											Optional:    true,
+											Description: "Application the filter applies to.",
+										},
+										policyAttributeKey: {
+											Type:         schema.TypeString,
+											Required:     true,
+											ValidateFunc: validation.StringIsNotWhiteSpace,
+											Description:  "Attribute of matched node, like `subject.property.username`.",
+										},
+										policyOperatorKey: {
+											Type:         schema.TypeString,
+											Required:     true,
+											ValidateFunc: validation.StringInSlice(policyFilterOperators, false),
+											Description: "Comparison operator, one of `=`, `<>`, `<`, `<=`, `>`, `>=`, " +
+												"`IN`, `CONTAINS`, `STARTS WITH` or `ENDS WITH`.",
+										},
+										valueKey: {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "Value to compare with, `$name` refers to input parameter.",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// compilePolicyBlock returns JSON of the policy block, or an empty string when the block is not set.
+func compilePolicyBlock(block any) (string, error) {
+	list, _ := block.([]any)
+	if len(list) == 0 || list[0] == nil {
+		return "", nil
+	}
+	raw := list[0].(map[string]any)
+	policy := kbacPolicy{
+		Meta:     kbacMeta{PolicyVersion: raw[policyVersionKey].(string)},
+		Subject:  kbacNode{Type: policyNodeType(raw[policySubjectKey])},
+		Resource: kbacNode{Type: policyNodeType(raw[policyResourceKey])},
+		Actions:  rawArrayToTypedArray[string](raw[policyActionsKey].([]any)),
+	}
+	if conditions, _ := raw[policyConditionKey].([]any); len(conditions) > 0 && conditions[0] != nil {
+		condition := conditions[0].(map[string]any)
+		policy.Condition = &kbacCondition{Cypher: condition[policyCypherKey].(string)}
+		for _, f := range condition[filterKey].([]any) {
+			filter := f.(map[string]any)
+			policy.Condition.Filter = append(policy.Condition.Filter, kbacFilter{
+				App:       filter[policyFilterAppKey].(string),
+				Attribute: filter[policyAttributeKey].(string),
+				Operator:  filter[policyOperatorKey].(string),
+				Value:     filter[valueKey].(string),
+			})
+		}
+	}
+	result, err := json.Marshal(policy)
+	return string(result), err
+}
+
+func policyNodeType(node any) string {
+	list, _ := node.([]any)
+	if len(list) == 0 || list[0] == nil {
+		return ""
+	}
+	value, _ := list[0].(map[string]any)[policyTypeKey].(string)
+	return value
+}
+
+// decompilePolicyBlock converts policy JSON back to the policy block.
+// It returns false when the policy uses anything the block cannot express.
+func decompilePolicyBlock(policyJSON string) ([]any, bool) {
+	var policy kbacPolicy
+	decoder := json.NewDecoder(bytes.NewReader([]byte(policyJSON)))
+	decoder.DisallowUnknownFields()
+	if decoder.Decode(&policy) != nil {
+		return nil, false
+	}
+	block := map[string]any{
+		policyVersionKey:  policy.Meta.PolicyVersion,
+		policySubjectKey:  []any{map[string]any{policyTypeKey: policy.Subject.Type}},
+		policyResourceKey: []any{map[string]any{policyTypeKey: policy.Resource.Type}},
+		policyActionsKey:  policy.Actions,
+	}
+	if policy.Condition != nil {
+		filters := make([]any, 0, len(policy.Condition.Filter))
+		for _, f := range policy.Condition.Filter {
+			filters = append(filters, map[string]any{
+				policyFilterAppKey: f.App,
+				policyAttributeKey: f.Attribute,
+				policyOperatorKey:  f.Operator,
+				valueKey:           f.Value,
+			})
+		}
+		block[policyConditionKey] = []any{map[string]any{
+			policyCypherKey: policy.Condition.Cypher,
+			filterKey:       filters,
+		}}
+	}
+	return []any{block}, true
+}
+
+// authzPolicyJSON returns the policy JSON to send to the API, compiled from the policy block when it is set.
+func authzPolicyJSON(data *schema.ResourceData) (string, error) {
+	if compiled, err := compilePolicyBlock(data.Get(authzPolicyKey)); compiled != "" || err != nil {
+		return compiled, err
+	}
+	return data.Get(authzJSONConfigKey).(string), nil
+}
+
+// authzPolicyCustomizeDiff plans the compiled JSON of the policy block, so the plan shows it as well.
+func authzPolicyCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if len(diff.Get(authzPolicyKey).([]any)) == 0 {
+		return nil
+	}
+	if !diff.NewValueKnown(authzPolicyKey) {
+		return diff.SetNewComputed(authzJSONConfigKey)
+	}
+	compiled, err := compilePolicyBlock(diff.Get(authzPolicyKey))
+	if err != nil {
+		return err
+	}
+	old, _ := diff.GetChange(authzJSONConfigKey)
+	if structure.SuppressJsonDiff(authzJSONConfigKey, old.(string), compiled, nil) {
+		return nil
+	}
+	return diff.SetNew(authzJSONConfigKey, compiled)
+}
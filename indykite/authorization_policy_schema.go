@@ -0,0 +1,122 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const authzPolicySchemaURL = "authorization_policy.json"
+
+// authzPolicySchemaDocument is JSON Schema of KBAC policy documents, so policies are checked without the API.
+//
+//go:embed schemas/authorization_policy.json
+var authzPolicySchemaDocument []byte
+
+var authzPolicySchema = sync.OnceValue(func() *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(authzPolicySchemaDocument))
+	if err != nil {
+		panic("invalid embedded authorization policy schema: " + err.Error())
+	}
+	compiler := jsonschema.NewCompiler()
+	if err = compiler.AddResource(authzPolicySchemaURL, doc); err != nil {
+		panic("invalid embedded authorization policy schema: " + err.Error())
+	}
+	return compiler.MustCompile(authzPolicySchemaURL)
+})
+
+// policySchemaViolation is a single mismatch of the policy and the schema.
+type policySchemaViolation struct {
+	// Pointer is JSON pointer to the offending value, empty for the whole document.
+	Pointer string
+	Message string
+}
+
+// validatePolicyDocument returns all schema violations of the policy sorted by the JSON pointer.
+// Error is returned only when the policy is not JSON at all.
+func validatePolicyDocument(policyJSON string) ([]policySchemaViolation, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(policyJSON))
+	if err != nil {
+		return nil, err
+	}
+	err = authzPolicySchema().Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	printer := message.NewPrinter(language.English)
+	var violations []policySchemaViolation
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		// Only leaves describe the problem, parents just say which keyword failed
+		if len(e.Causes) == 0 {
+			violations = append(violations, policySchemaViolation{
+				Pointer: jsonPointer(e.InstanceLocation),
+				Message: e.ErrorKind.LocalizedString(printer),
+			})
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(validationErr)
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Pointer < violations[j].Pointer })
+	return violations, nil
+}
+
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, token := range tokens {
+		sb.WriteString("/" + escape.Replace(token))
+	}
+	return sb.String()
+}
+
+// validateAuthzPolicyJSON checks the policy against the embedded schema at plan time.
+// Values, which are not JSON, are reported by validation.StringIsJSON.
+func validateAuthzPolicyJSON(value any, path cty.Path) diag.Diagnostics {
+	policyJSON, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	violations, err := validatePolicyDocument(policyJSON)
+	if err != nil {
+		return nil
+	}
+	var d diag.Diagnostics
+	for _, v := range violations {
+		d = append(d, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Authorization policy does not match the KBAC policy schema",
+			Detail:        fmt.Sprintf("At JSON pointer %q: %s", v.Pointer, v.Message),
+			AttributePath: path,
+		})
+	}
+	return d
+}
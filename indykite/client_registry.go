@@ -0,0 +1,99 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// clientRegistry shares REST clients between provider instances configured the same way.
+// Terraform configures every provider alias separately, so root modules with many aliases
+// would otherwise build a client, connection pool and token per alias.
+type clientRegistry struct {
+	clients map[string]*RestClient
+	mu      sync.Mutex
+}
+
+var sharedClients = &clientRegistry{clients: make(map[string]*RestClient)}
+
+// clientCacheKey returns a hash of resolved credentials, endpoint, retry and rate limit settings.
+// Credentials are hashed, so the key never reveals them.
+func clientCacheKey(cfg *RestClientConfig) (string, error) {
+	credentials, err := resolveCredentials(cfg)
+	if err != nil {
+		return "", err
+	}
+	retry := cfg.Retry.withDefaults()
+	rateLimit := cfg.RateLimit.withDefaults()
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		credentials,
+		cfg.BaseURL,
+		strings.ToLower(cfg.Region),
+		cfg.RequestTimeout.String(),
+		strconv.Itoa(retry.MaxAttempts),
+		retry.MinBackoff.String(),
+		retry.MaxBackoff.String(),
+		strconv.FormatFloat(rateLimit.RequestsPerSecond, 'g', -1, 64),
+		strconv.Itoa(rateLimit.Burst),
+		strconv.Itoa(rateLimit.MaxInFlight),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// get returns the client registered for given configuration, creating it on the first use.
+func (r *clientRegistry) get(ctx context.Context, cfg *RestClientConfig) (*RestClient, error) {
+	key, err := clientCacheKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if client, ok := r.clients[key]; ok {
+		return client, nil
+	}
+	client, err := NewRestClientWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[key] = client
+	return client, nil
+}
+
+// RegisterClient stores the REST client into the shared registry, so every provider instance
+// configured with the same settings uses it. This allows tests to inject a client per provider alias.
+// Returned function removes the client from the registry again.
+func RegisterClient(cfg *RestClientConfig, client *RestClient) (func(), error) {
+	key, err := clientCacheKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedClients.mu.Lock()
+	defer sharedClients.mu.Unlock()
+	sharedClients.clients[key] = client
+	return func() {
+		sharedClients.mu.Lock()
+		defer sharedClients.mu.Unlock()
+		if sharedClients.clients[key] == client {
+			delete(sharedClients.clients, key)
+		}
+	}, nil
+}
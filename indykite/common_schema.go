@@ -50,6 +50,7 @@ const (
 	dbNameKey             = "name"
 	dbCompositeDBNameKey  = "composite_db_name"
 	dbAliasMappingKey     = "alias_mapping"
+	etagKey               = "etag"
 )
 
 const (
@@ -161,6 +162,15 @@ func updateTimeSchema() *schema.Schema {
 	}
 }
 
+func etagSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: `Version of the Resource assigned by the server. It is sent with every update and delete, ` +
+			`which fail when the Resource was modified outside of Terraform since the last refresh.`,
+	}
+}
+
 func displayNameSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:             schema.TypeString,
@@ -221,6 +231,12 @@ func apiPermissionsSchema() *schema.Schema {
 	}
 }
 
+// dbPasswordOneOf requires either password stored in the state or its write-only variant.
+var dbPasswordOneOf = []string{
+	dbConnectionKey + ".0." + dbPasswordKey,
+	dbConnectionKey + ".0." + dbPasswordKey + writeOnlySuffix,
+}
+
 func dbConnectionSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeList,
@@ -242,11 +258,31 @@ func dbConnectionSchema() *schema.Schema {
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 				dbPasswordKey: {
-					Type:         schema.TypeString,
-					Required:     true,
-					Sensitive:    true,
-					Description:  "Password for database authentication",
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Description: "Password for database authentication, stored in the state. " +
+						"Use `password_wo` to keep it out of the state.",
+					ValidateFunc:  validation.StringIsNotEmpty,
+					ExactlyOneOf:  dbPasswordOneOf,
+					ConflictsWith: []string{dbConnectionKey + ".0." + dbPasswordKey + writeOnlyVersionSuffix},
+				},
+				dbPasswordKey + writeOnlySuffix: {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					WriteOnly: true,
+					Description: "Write-only password for database authentication, never stored in the plan or state. " +
+						"Change `password_wo_version` to send a new value. Requires Terraform 1.11 or later.",
 					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: dbPasswordOneOf,
+				},
+				dbPasswordKey + writeOnlyVersionSuffix: {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Description: "Version of `password_wo`, any change triggers update " +
+						"sending the current write-only password.",
+					RequiredWith: []string{dbConnectionKey + ".0." + dbPasswordKey + writeOnlySuffix},
 				},
 				dbNameKey: {
 					Type:        schema.TypeString,
@@ -0,0 +1,545 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateOptions selects the part of IndyKite configuration written by GenerateConfig.
+type GenerateOptions struct {
+	// OrganizationID is walked when ProjectID is empty; defaults to the organization of the credentials.
+	OrganizationID string
+	// ProjectID limits the walk to a single Application Space and its configuration.
+	ProjectID string
+}
+
+// generatedResource pairs Terraform resource type with the import spec,
+// whose path and scope parameter are used for listing.
+type generatedResource struct {
+	spec         *importSpec
+	resourceType string
+}
+
+// Credentials are not generated, their private keys are returned by the API only when created.
+var (
+	generatedOrganizationResources = []generatedResource{
+		{resourceType: "indykite_service_account", spec: serviceAccountImport},
+	}
+	// generatedProjectResources are ordered so referenced resources precede the ones referencing them.
+	generatedProjectResources = []generatedResource{
+		{resourceType: "indykite_application", spec: applicationImport},
+		{resourceType: "indykite_application_agent", spec: appAgentImport},
+		{resourceType: "indykite_authorization_policy", spec: authorizationPolicyImport},
+		{resourceType: "indykite_token_introspect", spec: tokenIntrospectImport},
+		{resourceType: "indykite_external_data_resolver", spec: externalDataResolverImport},
+		{resourceType: "indykite_entity_matching_pipeline", spec: entityMatchingPipelineImport},
+		{resourceType: "indykite_trust_score_profile", spec: trustScoreProfileImport},
+		{resourceType: "indykite_knowledge_query", spec: knowledgeQueryImport},
+		{resourceType: "indykite_event_sink", spec: eventSinkImport},
+		{resourceType: "indykite_mcp_server", spec: mcpServerImport},
+	}
+
+	// Attributes written first in every resource block, the rest follows in alphabetical order.
+	generatedLeadingKeys = []string{locationKey, customerIDKey, appSpaceIDKey, nameKey, displayNameKey, descriptionKey}
+
+	invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// configGenerator accumulates generated blocks; resources are read through the provider server,
+// so generated attributes always match what the provider would store in the state.
+type configGenerator struct {
+	client  *RestClient
+	server  tfprotov6.ProviderServer
+	schemas map[string]*tfprotov6.Schema
+	// addresses maps GID of every generated resource to its Terraform address, used for references.
+	addresses map[string]string
+	// labels holds used resource labels per resource type.
+	labels    map[string]bool
+	variables *hclwrite.File
+	blocks    *hclwrite.File
+	imports   *hclwrite.File
+}
+
+// GenerateConfig walks the organization, or a single Application Space, and writes Terraform configuration
+// with resource and import blocks of all supported resources into w.
+// Sensitive attributes are replaced by variables declared in the output.
+func GenerateConfig(ctx context.Context, client *RestClient, opts GenerateOptions, w io.Writer) error {
+	server, schemas, err := configuredProviderServer(ctx, client)
+	if err != nil {
+		return err
+	}
+	g := &configGenerator{
+		client:    client,
+		server:    server,
+		schemas:   schemas,
+		addresses: map[string]string{},
+		labels:    map[string]bool{},
+		variables: hclwrite.NewEmptyFile(),
+		blocks:    hclwrite.NewEmptyFile(),
+		imports:   hclwrite.NewEmptyFile(),
+	}
+
+	var appSpaceIDs []string
+	if opts.ProjectID != "" {
+		appSpaceIDs = []string{opts.ProjectID}
+	} else {
+		orgID := opts.OrganizationID
+		if orgID == "" {
+			var org CustomerResponse
+			if err = client.Get(ctx, "/organizations/current", &org); err != nil {
+				return fmt.Errorf("cannot read current organization: %w", err)
+			}
+			orgID = org.ID
+		}
+
+		appSpaceIDs, err = listIDs(ctx, client, appSpaceImport, orgID)
+		if err != nil {
+			return err
+		}
+		for _, res := range generatedOrganizationResources {
+			if err = g.generateAll(ctx, res, orgID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, appSpaceID := range appSpaceIDs {
+		if err = g.generate(ctx, "indykite_application_space", appSpaceID); err != nil {
+			return err
+		}
+		for _, res := range generatedProjectResources {
+			if err = g.generateAll(ctx, res, appSpaceID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return g.write(w)
+}
+
+// configuredProviderServer returns the same server Terraform talks to, configured to use given client,
+// together with schemas of all resources.
+func configuredProviderServer(
+	ctx context.Context,
+	client *RestClient,
+) (tfprotov6.ProviderServer, map[string]*tfprotov6.Schema, error) {
+	factory, err := ProviderServerFactory(ctx, Provider())
+	if err != nil {
+		return nil, nil, err
+	}
+	server := factory()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = diagnosticsError(schemaResp.Diagnostics); err != nil {
+		return nil, nil, fmt.Errorf("cannot read provider schema: %w", err)
+	}
+
+	providerType := schemaResp.Provider.ValueType()
+	config, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	configResp, err := server.ConfigureProvider(WithClient(ctx, client), &tfprotov6.ConfigureProviderRequest{
+		Config: &config,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = diagnosticsError(configResp.Diagnostics); err != nil {
+		return nil, nil, fmt.Errorf("cannot configure provider: %w", err)
+	}
+	return server, schemaResp.ResourceSchemas, nil
+}
+
+func diagnosticsError(diagnostics []*tfprotov6.Diagnostic) error {
+	var errs []error
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, fmt.Errorf("%s %s", d.Summary, d.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *configGenerator) generateAll(ctx context.Context, res generatedResource, scopeID string) error {
+	ids, err := listIDs(ctx, g.client, res.spec, scopeID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err = g.generate(ctx, res.resourceType, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listIDs returns GIDs of all entries of the collection within given scope.
+func listIDs(ctx context.Context, client *RestClient, spec *importSpec, scopeID string) ([]string, error) {
+	var ids []string
+	path := spec.path + "?" + spec.scopeQueryParam + "=" + scopeID
+	err := listAllPages(ctx, client, path, func(item *BaseResponse) bool {
+		ids = append(ids, item.ID)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list %s in %s: %w", spec.path, scopeID, err)
+	}
+	return ids, nil
+}
+
+// generate reads single resource and appends its resource and import blocks.
+func (g *configGenerator) generate(ctx context.Context, resourceType, id string) error {
+	resSchema := g.schemas[resourceType]
+	stateType := resSchema.ValueType()
+	state, err := tfprotov6.NewDynamicValue(stateType, importedState(resSchema.Block, id))
+	if err != nil {
+		return err
+	}
+	readResp, err := g.server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     resourceType,
+		CurrentState: &state,
+	})
+	if err == nil {
+		err = diagnosticsError(readResp.Diagnostics)
+	}
+	var newState tftypes.Value
+	if err == nil {
+		newState, err = readResp.NewState.Unmarshal(stateType)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read %s %s: %w", resourceType, id, err)
+	}
+	if newState.IsNull() {
+		// Deleted between listing and reading
+		return nil
+	}
+
+	values, _ := stateValue(newState).(map[string]any)
+	name, _ := values[nameKey].(string)
+	label := g.uniqueLabel(resourceType, name)
+	address := resourceType + "." + label
+
+	block := g.blocks.Body().AppendNewBlock("resource", []string{resourceType, label})
+	g.writeBody(block.Body(), resSchema.Block, values, strings.TrimPrefix(resourceType, "indykite_")+"_"+label, 1)
+	g.blocks.Body().AppendNewline()
+
+	importBody := g.imports.Body().AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(id))
+	g.imports.Body().AppendNewline()
+
+	g.addresses[id] = address
+	return nil
+}
+
+// importedState returns the state as Terraform sends it for read right after import, only the ID is known.
+func importedState(block *tfprotov6.SchemaBlock, id string) tftypes.Value {
+	objectType, _ := block.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for key, attrType := range objectType.AttributeTypes {
+		values[key] = tftypes.NewValue(attrType, nil)
+	}
+	for _, nested := range block.BlockTypes {
+		if nested.Nesting == tfprotov6.SchemaNestedBlockNestingModeList ||
+			nested.Nesting == tfprotov6.SchemaNestedBlockNestingModeSet {
+			values[nested.TypeName] = tftypes.NewValue(objectType.AttributeTypes[nested.TypeName], []tftypes.Value{})
+		}
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, id)
+	return tftypes.NewValue(objectType, values)
+}
+
+// stateValue converts state value into plain Go values, objects and maps become map[string]any,
+// lists and sets []any and whole numbers int.
+func stateValue(value tftypes.Value) any {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	valueType := value.Type()
+	switch {
+	case valueType.Is(tftypes.List{}), valueType.Is(tftypes.Set{}), valueType.Is(tftypes.Tuple{}):
+		var items []tftypes.Value
+		_ = value.As(&items)
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i] = stateValue(item)
+		}
+		return result
+	case valueType.Is(tftypes.Map{}), valueType.Is(tftypes.Object{}):
+		var items map[string]tftypes.Value
+		_ = value.As(&items)
+		result := make(map[string]any, len(items))
+		for key, item := range items {
+			result[key] = stateValue(item)
+		}
+		return result
+	case valueType.Is(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		return s
+	case valueType.Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return b
+	case valueType.Is(tftypes.Number):
+		var n big.Float
+		_ = value.As(&n)
+		if n.IsInt() {
+			i, _ := n.Int64()
+			return int(i)
+		}
+		f, _ := n.Float64()
+		return f
+	}
+	return nil
+}
+
+func (g *configGenerator) uniqueLabel(resourceType, name string) string {
+	base := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+	label := base
+	for i := 2; g.labels[resourceType+"."+label]; i++ {
+		label = base + "_" + strconv.Itoa(i)
+	}
+	g.labels[resourceType+"."+label] = true
+	return label
+}
+
+// writeBody writes all configurable attributes and nested blocks with values.
+// Optional attributes with zero value are omitted, as the API does not return them.
+// Secrets with write-only variant are always written as write-only attributes.
+func (g *configGenerator) writeBody(
+	body *hclwrite.Body,
+	block *tfprotov6.SchemaBlock,
+	values map[string]any,
+	varPrefix string,
+	depth int,
+) {
+	attributes := make(map[string]*tfprotov6.SchemaAttribute, len(block.Attributes))
+	for _, attr := range block.Attributes {
+		attributes[attr.Name] = attr
+	}
+	nestedBlocks := make(map[string]*tfprotov6.SchemaNestedBlock, len(block.BlockTypes))
+	for _, nested := range block.BlockTypes {
+		nestedBlocks[nested.TypeName] = nested
+	}
+
+	for _, key := range orderedKeys(attributes, nestedBlocks) {
+		value := values[key]
+		if nested, ok := nestedBlocks[key]; ok {
+			if key == timeoutsKey {
+				continue
+			}
+			items, _ := value.([]any)
+			if itemValues, isObject := value.(map[string]any); isObject {
+				items = []any{itemValues}
+			}
+			for i, item := range items {
+				itemValues, _ := item.(map[string]any)
+				nestedBody := body.AppendNewBlock(key, nil).Body()
+				g.writeBody(nestedBody, nested.Block, itemValues, varPrefix+"_"+key+"_"+strconv.Itoa(i), depth+1)
+			}
+			continue
+		}
+
+		attr := attributes[key]
+		// ID is given by the import block, deletion protection is not part of the API and keeps its default
+		if key == "id" || key == deletionProtectionKey || (!attr.Required && !attr.Optional) || attr.Deprecated {
+			continue
+		}
+		switch {
+		case attr.WriteOnly:
+			// Write-only variant keeps the secret out of the state, its version starts with 1
+			secretKey := strings.TrimSuffix(key, writeOnlySuffix)
+			body.SetAttributeTraversal(key, g.declareVariable(varPrefix+"_"+secretKey, attr.Description))
+			body.SetAttributeValue(secretKey+writeOnlyVersionSuffix, cty.NumberIntVal(1))
+		case !attr.Required && isZeroValue(value):
+			continue
+		case attr.Sensitive:
+			body.SetAttributeTraversal(key, g.declareVariable(varPrefix+"_"+key, attr.Description))
+		default:
+			body.SetAttributeRaw(key, g.valueTokens(value, depth))
+		}
+	}
+}
+
+// valueTokens renders the value, GIDs of generated resources become references
+// and JSON documents are pretty-printed into heredoc.
+func (g *configGenerator) valueTokens(value any, depth int) hclwrite.Tokens {
+	switch v := value.(type) {
+	case string:
+		if address, ok := g.addresses[v]; ok {
+			return hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: strings.Split(address, ".")[0]},
+				hcl.TraverseAttr{Name: strings.Split(address, ".")[1]},
+				hcl.TraverseAttr{Name: "id"},
+			})
+		}
+		if heredoc := jsonHeredocTokens(v, depth); heredoc != nil {
+			return heredoc
+		}
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case []any:
+		items := make([]hclwrite.Tokens, len(v))
+		for i, item := range v {
+			items[i] = g.valueTokens(item, depth+1)
+		}
+		return hclwrite.TokensForTuple(items)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		items := make([]hclwrite.ObjectAttrTokens, len(keys))
+		for i, key := range keys {
+			items[i] = hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(key)),
+				Value: g.valueTokens(v[key], depth+1),
+			}
+		}
+		return hclwrite.TokensForObject(items)
+	default:
+		return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(v)))
+	}
+}
+
+func (g *configGenerator) declareVariable(name, description string) hcl.Traversal {
+	body := g.variables.Body().AppendNewBlock("variable", []string{name}).Body()
+	body.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	if description != "" {
+		body.SetAttributeValue("description", cty.StringVal(description))
+	}
+	body.SetAttributeValue("sensitive", cty.True)
+	g.variables.Body().AppendNewline()
+	return hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: name}}
+}
+
+func (g *configGenerator) write(w io.Writer) error {
+	if len(g.addresses) == 0 {
+		return errors.New("no supported resources found")
+	}
+	var out bytes.Buffer
+	out.WriteString("# Generated by terraform-provider-indykite generate\n\n")
+	out.Write(g.variables.Bytes())
+	out.Write(g.blocks.Bytes())
+	out.Write(g.imports.Bytes())
+	_, err := w.Write(hclwrite.Format(bytes.TrimRight(out.Bytes(), "\n")))
+	if err == nil {
+		_, err = io.WriteString(w, "\n")
+	}
+	return err
+}
+
+// jsonHeredocTokens returns heredoc with indented JSON, when value is JSON object or array.
+// Formatter does not touch heredoc content, so it is indented according to the depth of attribute.
+func jsonHeredocTokens(value string, depth int) hclwrite.Tokens {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil
+	}
+	var pretty bytes.Buffer
+	indent := strings.Repeat("  ", depth)
+	if err := json.Indent(&pretty, []byte(trimmed), indent+"  ", "  "); err != nil {
+		return nil
+	}
+	// Heredoc is a template, interpolation sequences in JSON must be escaped
+	content := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(pretty.String())
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<-EOT\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(indent + "  " + content + "\n")},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(indent + "EOT")},
+	}
+}
+
+func orderedKeys(
+	attributes map[string]*tfprotov6.SchemaAttribute,
+	blocks map[string]*tfprotov6.SchemaNestedBlock,
+) []string {
+	names := make([]string, 0, len(attributes)+len(blocks))
+	for key := range attributes {
+		names = append(names, key)
+	}
+	for key := range blocks {
+		names = append(names, key)
+	}
+	slices.Sort(names)
+
+	keys := make([]string, 0, len(names))
+	for _, key := range generatedLeadingKeys {
+		if slices.Contains(names, key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range names {
+		if !slices.Contains(generatedLeadingKeys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func isZeroValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config generator", func() {
+	const (
+		policyID = "gid:AAAAFXBvbGljeUlEAAAAAAAAAAA"
+		queryID  = "gid:AAAAFnF1ZXJ5SURxdWVyeUlEAAA"
+	)
+	var (
+		mockServer *httptest.Server
+		client     *indykite.RestClient
+		responses  map[string]any
+	)
+
+	BeforeEach(func() {
+		responses = map[string]any{
+			"/configs/v1/organizations/current": indykite.CustomerResponse{ID: customerID, Name: "acme"},
+			"/configs/v1/projects?organization_id=" + customerID: indykite.ListResponse[indykite.BaseResponse]{
+				Data: []indykite.BaseResponse{{ID: appSpaceID}},
+			},
+			"/configs/v1/projects/" + appSpaceID: indykite.ApplicationSpaceResponse{
+				ID: appSpaceID, CustomerID: customerID, Name: "my-space", DisplayName: "My Space",
+				Region: "europe-west1",
+			},
+			"/configs/v1/authorization-policies?project_id=" + appSpaceID: indykite.ListResponse[indykite.BaseResponse]{
+				Data: []indykite.BaseResponse{{ID: policyID}},
+			},
+			"/configs/v1/authorization-policies/" + policyID: indykite.AuthorizationPolicyResponse{
+				ID: policyID, CustomerID: customerID, AppSpaceID: appSpaceID, Name: "my-policy",
+				Policy: `{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"${Person}"}}`, Status: "ACTIVE",
+			},
+			"/configs/v1/knowledge-queries?project_id=" + appSpaceID: indykite.ListResponse[indykite.BaseResponse]{
+				Data: []indykite.BaseResponse{{ID: queryID}},
+			},
+			"/configs/v1/knowledge-queries/" + queryID: indykite.KnowledgeQueryResponse{
+				ID: queryID, CustomerID: customerID, AppSpaceID: appSpaceID, Name: "my-query",
+				Query: `{"nodes":["person"]}`, Status: "ACTIVE", PolicyID: policyID,
+			},
+			"/configs/v1/event-sinks?project_id=" + appSpaceID: indykite.ListResponse[indykite.BaseResponse]{
+				Data: []indykite.BaseResponse{{ID: sampleID}},
+			},
+			"/configs/v1/event-sinks/" + sampleID: indykite.EventSinkResponse{
+				ID: sampleID, CustomerID: customerID, AppSpaceID: appSpaceID, Name: "my-sink",
+				Config: map[string]any{
+					"providers": map[string]any{
+						"kafka2": map[string]any{
+							"kafka": map[string]any{
+								"brokers":  []string{"my.kafka.server.example.com:9092"},
+								"topic":    "my-kafka-topic",
+								"username": "my-username",
+							},
+						},
+					},
+					"routes": []any{
+						map[string]any{
+							"providerId": "kafka2",
+							"keysValues": map[string]any{"eventType": "indykite.audit.config.create"},
+						},
+					},
+				},
+			},
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if resp, ok := responses[r.URL.RequestURI()]; ok {
+				_ = json.NewEncoder(w).Encode(resp)
+				return
+			}
+			// All other collections are empty
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}))
+		client = indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	It("generates resources, references, variables and imports of organization", func() {
+		var out bytes.Buffer
+		Expect(indykite.GenerateConfig(context.Background(), client, indykite.GenerateOptions{}, &out)).To(Succeed())
+		generated := out.String()
+
+		_, parseDiags := hclsyntax.ParseConfig(out.Bytes(), "generated.tf", hcl.InitialPos)
+		Expect(parseDiags.HasErrors()).To(BeFalse(), parseDiags.Error())
+
+		Expect(generated).To(ContainSubstring(`resource "indykite_application_space" "my_space" {`))
+		Expect(generated).To(ContainSubstring(`customer_id  = "` + customerID + `"`))
+		Expect(generated).To(ContainSubstring(`region       = "europe-west1"`))
+		Expect(generated).NotTo(ContainSubstring("deletion_protection"))
+		Expect(generated).NotTo(ContainSubstring("create_time"))
+
+		Expect(generated).To(ContainSubstring(`resource "indykite_authorization_policy" "my_policy" {`))
+		Expect(generated).To(ContainSubstring("location = indykite_application_space.my_space.id"))
+		Expect(generated).To(ContainSubstring("  json     = <<-EOT\n    {\n      \"meta\": {\n"))
+		Expect(generated).To(ContainSubstring(`"type": "$${Person}"`))
+		Expect(generated).To(ContainSubstring(`status   = "active"`))
+
+		Expect(generated).To(ContainSubstring("policy_id = indykite_authorization_policy.my_policy.id"))
+
+		Expect(generated).To(ContainSubstring(`variable "event_sink_my_sink_providers_0_kafka_0_password" {`))
+		Expect(generated).To(ContainSubstring(
+			"password_wo         = var.event_sink_my_sink_providers_0_kafka_0_password"))
+		Expect(generated).To(ContainSubstring("password_wo_version = 1"))
+		Expect(generated).To(ContainSubstring(`topic               = "my-kafka-topic"`))
+
+		Expect(generated).To(ContainSubstring("import {\n  to = indykite_knowledge_query.my_query\n  id = \"" +
+			queryID + "\"\n}"))
+	})
+
+	It("walks single application space", func() {
+		var out bytes.Buffer
+		opts := indykite.GenerateOptions{ProjectID: appSpaceID}
+		Expect(indykite.GenerateConfig(context.Background(), client, opts, &out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring(`resource "indykite_application_space" "my_space" {`))
+		Expect(out.String()).To(ContainSubstring(`resource "indykite_event_sink" "my_sink" {`))
+	})
+
+	It("fails on API errors", func() {
+		mockServer.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		err := indykite.GenerateConfig(context.Background(), client, indykite.GenerateOptions{}, &bytes.Buffer{})
+		Expect(err).To(MatchError(ContainSubstring("cannot read current organization")))
+	})
+})
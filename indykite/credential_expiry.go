@@ -0,0 +1,57 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const expiringWithinKey = "expiring_within"
+
+// warnCredentialExpiry appends warning when the credential expires within credential_expiry_warning
+// of the provider configuration. Credentials without expiration never warn.
+func (x *ClientContext) warnCredentialExpiry(d *diag.Diagnostics, id, kid string, expireTime time.Time) {
+	if x.config == nil || x.config.credentialExpiryWarning <= 0 || expireTime.IsZero() {
+		return
+	}
+	remaining := time.Until(expireTime)
+	if remaining > x.config.credentialExpiryWarning {
+		return
+	}
+	summary := "Credential expires soon"
+	if remaining <= 0 {
+		summary = "Credential has expired"
+	}
+	*d = append(*d, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail: fmt.Sprintf("Credential %s with kid %q expires at %s, which is within %s of %s. "+
+			"Rotate it before consumers start to fail.",
+			id, kid, expireTime.UTC().Format(time.RFC3339),
+			credentialExpiryWarningKey, x.config.credentialExpiryWarning),
+	})
+}
+
+// parseExpireTime parses expiration returned by the API, empty or invalid value means no expiration.
+func parseExpireTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
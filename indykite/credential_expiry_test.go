@@ -0,0 +1,135 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential expiry warnings", func() {
+	var (
+		mockServer  *httptest.Server
+		provider    *schema.Provider
+		credentials []indykite.ServiceAccountCredentialResponse
+	)
+
+	expireIn := func(d time.Duration) string {
+		return time.Now().Add(d).UTC().Format(time.RFC3339)
+	}
+
+	BeforeEach(func() {
+		credentials = []indykite.ServiceAccountCredentialResponse{
+			{ID: "soon", Kid: "kid-soon", ExpireTime: expireIn(time.Hour)},
+			{ID: "later", Kid: "kid-later", ExpireTime: expireIn(48 * time.Hour)},
+			{ID: "never", Kid: "kid-never"},
+			{ID: "expired", Kid: "kid-expired", ExpireTime: expireIn(-time.Hour)},
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, ok := strings.CutPrefix(r.URL.Path, "/configs/v1/service-account-credentials/"); ok {
+				for _, credential := range credentials {
+					if credential.ID == id {
+						_ = json.NewEncoder(w).Encode(credential)
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			Expect(r.URL.Query().Get("organization_id")).To(Equal(customerID))
+			_ = json.NewEncoder(w).Encode(indykite.ListResponse[indykite.ServiceAccountCredentialResponse]{
+				Data: credentials,
+			})
+		}))
+
+		provider = indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	configure := func(raw map[string]any) any {
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))).To(BeEmpty())
+		return provider.Meta()
+	}
+	readList := func(meta any, raw map[string]any) (*schema.ResourceData, diag.Diagnostics) {
+		dataSource := provider.DataSourcesMap["indykite_service_account_credentials"]
+		raw["customer_id"] = customerID
+		data := schema.TestResourceDataRaw(GinkgoT(), dataSource.Schema, raw)
+		return data, dataSource.ReadContext(context.Background(), data, meta)
+	}
+	listIDs := func(data *schema.ResourceData) []string {
+		ids := []string{}
+		for _, entry := range data.Get("service_account_credentials").([]any) {
+			ids = append(ids, entry.(map[string]any)["id"].(string))
+		}
+		return ids
+	}
+
+	It("warns about listed credentials expiring within the window", func() {
+		data, d := readList(configure(map[string]any{"credential_expiry_warning": "24h"}), map[string]any{})
+		Expect(listIDs(data)).To(Equal([]string{"soon", "later", "never", "expired"}))
+		Expect(d).To(HaveLen(2))
+		Expect(d).To(HaveEach(HaveField("Severity", diag.Warning)))
+		Expect(d[0].Summary).To(Equal("Credential expires soon"))
+		Expect(d[0].Detail).To(ContainSubstring(`Credential soon with kid "kid-soon"`))
+		Expect(d[1].Summary).To(Equal("Credential has expired"))
+	})
+
+	It("does not warn without credential_expiry_warning", func() {
+		_, d := readList(configure(nil), map[string]any{})
+		Expect(d).To(BeEmpty())
+	})
+
+	It("filters credentials expiring within given duration", func() {
+		data, d := readList(configure(nil), map[string]any{"expiring_within": "72h"})
+		Expect(d).To(BeEmpty())
+		Expect(listIDs(data)).To(Equal([]string{"soon", "later", "expired"}))
+		Expect(data.Id()).To(Equal(customerID + "/service_account_credentials/expiring_within=72h"))
+	})
+
+	It("warns when reading credential resource", func() {
+		meta := configure(map[string]any{"credential_expiry_warning": "2h"})
+		res := provider.ResourcesMap["indykite_service_account_credential"]
+		read := func(id string) diag.Diagnostics {
+			data := res.TestResourceData()
+			data.SetId(id)
+			return res.ReadContext(context.Background(), data, meta)
+		}
+
+		Expect(read("soon")).To(ConsistOf(HaveField("Summary", "Credential expires soon")))
+		Expect(read("later")).To(BeEmpty())
+		Expect(read("never")).To(BeEmpty())
+	})
+})
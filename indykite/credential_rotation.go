@@ -0,0 +1,342 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	rotationKey           = "rotation"
+	rotationTriggerKey    = "rotation_trigger"
+	validityKey           = "validity"
+	rotateBeforeKey       = "rotate_before"
+	overlapKey            = "overlap"
+	previousIDKey         = "previous_id"
+	previousKidKey        = "previous_kid"
+	previousDeleteTimeKey = "previous_delete_time"
+	previousPrefix        = "previous_"
+
+	defaultRotationOverlap = "24h"
+)
+
+// createCredentialFunc creates new credential expiring at expireTime, which might be empty,
+// and returns its ID together with the configuration, which the API returns only on creation.
+type createCredentialFunc func(ctx context.Context, expireTime string) (id, config string, err error)
+
+// credentialRotation rotates credentials in place instead of replacing them.
+// The new credential is created first, and the current one is kept as previous until
+// the overlap window ends, so consumers can switch to the new one without downtime.
+type credentialRotation struct {
+	// path of the credentials collection, like "/service-account-credentials/".
+	path string
+	// configKey is the key of the credential configuration returned on creation only.
+	configKey string
+}
+
+func rotationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{expireTimeKey},
+		Description: "Rotate the credential automatically before it expires. " +
+			"Rotation is evaluated during plan, so `terraform apply` must run regularly. " +
+			"Credential without expiration is rotated by the next apply.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				validityKey: {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: ValidateDuration,
+					DiffSuppressFunc: SuppressDurationDiff,
+					Description:      "Lifetime of every created credential as Go duration, for example `2160h`.",
+				},
+				rotateBeforeKey: {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: ValidateDuration,
+					DiffSuppressFunc: SuppressDurationDiff,
+					Description:      "Create new credential when the current one expires within this duration.",
+				},
+				overlapKey: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          defaultRotationOverlap,
+					ValidateDiagFunc: ValidateDuration,
+					DiffSuppressFunc: SuppressDurationDiff,
+					Description: "How long the previous credential is kept after rotation, " +
+						"defaults to `" + defaultRotationOverlap + "`.",
+				},
+			},
+		},
+	}
+}
+
+func rotationTriggerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Arbitrary value, any change rotates the credential. " +
+			"Without `rotation` block, the previous credential is deleted right after the new one is created.",
+	}
+}
+
+// schema returns computed attributes holding the previous credential during the overlap window.
+func (r credentialRotation) schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		previousIDKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Identifier of the previous credential, kept until `previous_delete_time` after rotation.",
+		},
+		previousKidKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Key identifier of the previous credential.",
+		},
+		previousPrefix + r.configKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "JSON configuration of the previous credential.",
+		},
+		previousDeleteTimeKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "Date-time after which the previous credential is deleted " +
+				"by the next `terraform apply`.",
+		},
+	}
+}
+
+// customizeDiff plans in-place update whenever credential must be rotated,
+// or the previous credential must be deleted.
+func (r credentialRotation) customizeDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	// Configured expiration cannot be changed, but rotation changes it in place
+	if diff.HasChange(expireTimeKey) {
+		return diff.ForceNew(expireTimeKey)
+	}
+	// Computed expiration keeps the old value when it is removed from the configuration,
+	// but the credential would still expire, so it must be replaced as well
+	if expireTimeRemoved(diff) {
+		if err := diff.SetNew(expireTimeKey, ""); err != nil {
+			return err
+		}
+		return diff.ForceNew(expireTimeKey)
+	}
+	expireTime, _ := diff.GetChange(expireTimeKey)
+	rotate := diff.HasChange(rotationTriggerKey) || rotationDue(diff.Get(rotationKey), expireTime.(string))
+	if rotate {
+		keys := []string{kidKey, r.configKey, createTimeKey}
+		if len(diff.Get(rotationKey).([]any)) > 0 {
+			keys = append(keys, expireTimeKey)
+		}
+		for _, key := range keys {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	if !rotate && !previousExpired(diff.Get(previousDeleteTimeKey).(string)) {
+		return nil
+	}
+	for key := range r.schema() {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newExpireTime returns expiration of credential created now.
+func newExpireTime(data *schema.ResourceData) string {
+	if validity, ok := rotationValue(data.Get(rotationKey), validityKey); ok {
+		return time.Now().Add(validity).UTC().Format(time.RFC3339)
+	}
+	return data.Get(expireTimeKey).(string)
+}
+
+// update deletes the previous credential once its overlap window ends,
+// and rotates the current one when requested or when it is about to expire.
+// It returns config of the new credential, or an empty string when there was no rotation.
+func (r credentialRotation) update(
+	ctx context.Context,
+	d *diag.Diagnostics,
+	client *RestClient,
+	data *schema.ResourceData,
+	create createCredentialFunc,
+) string {
+	// Decisions were made by customizeDiff at plan time, which plans kid as unknown to rotate
+	// and previous credential as unknown to delete it, so apply does not depend on the clock
+	rotate := plannedUnknown(data, kidKey)
+	if !rotate && !plannedUnknown(data, previousIDKey) {
+		r.keepPrevious(d, data)
+		return ""
+	}
+	// Only one previous credential is kept, so it is deleted also when rotating again within the window
+	if !r.deletePrevious(ctx, d, client, data) || !rotate {
+		return ""
+	}
+
+	oldKid, _ := data.GetChange(kidKey)
+	oldConfig, _ := data.GetChange(r.configKey)
+	previousID := data.Id()
+
+	id, config, err := create(ctx, newExpireTime(data))
+	if HasFailed(d, err) {
+		return ""
+	}
+	data.SetId(id)
+
+	// Previous credential is recorded before it is deleted, so the next apply retries failed deletion
+	overlap, hasOverlap := rotationValue(data.Get(rotationKey), overlapKey)
+	setData(d, data, previousIDKey, previousID)
+	setData(d, data, previousKidKey, oldKid)
+	setData(d, data, previousPrefix+r.configKey, oldConfig)
+	setData(d, data, previousDeleteTimeKey, time.Now().Add(overlap).UTC().Format(time.RFC3339))
+	if hasOverlap {
+		return config
+	}
+
+	// Without rotation block, there is no overlap window
+	err = client.Delete(ctx, r.path+previousID)
+	if err != nil && !IsNotFoundError(err) {
+		// Failing here would lose configuration of the new credential, which is returned only once
+		*d = append(*d, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Previous credential was not deleted",
+			Detail: "Credential " + previousID + " is kept as previous and the next apply deletes it: " +
+				err.Error(),
+		})
+		return config
+	}
+	for key := range r.schema() {
+		setData(d, data, key, "")
+	}
+	return config
+}
+
+// keepPrevious restores values of the previous credential,
+// which might be planned as unknown even though nothing changed.
+func (r credentialRotation) keepPrevious(d *diag.Diagnostics, data *schema.ResourceData) {
+	for key := range r.schema() {
+		old, _ := data.GetChange(key)
+		setData(d, data, key, old)
+	}
+}
+
+// deletePrevious deletes previous credential if there is any and clears all its attributes.
+func (r credentialRotation) deletePrevious(
+	ctx context.Context,
+	d *diag.Diagnostics,
+	client *RestClient,
+	data *schema.ResourceData,
+) bool {
+	previousID, _ := data.GetChange(previousIDKey)
+	if previousID.(string) != "" {
+		err := client.Delete(ctx, r.path+previousID.(string))
+		if err != nil && !IsNotFoundError(err) {
+			HasFailed(d, err)
+			return false
+		}
+	}
+	for key := range r.schema() {
+		setData(d, data, key, "")
+	}
+	return true
+}
+
+// delete deletes the previous credential together with the current one.
+func (r credentialRotation) delete(
+	ctx context.Context,
+	d *diag.Diagnostics,
+	client *RestClient,
+	data *schema.ResourceData,
+) {
+	if previousID := data.Get(previousIDKey).(string); previousID != "" {
+		err := client.Delete(ctx, r.path+previousID)
+		if err != nil && !IsNotFoundError(err) {
+			HasFailed(d, err)
+			return
+		}
+	}
+	err := client.Delete(ctx, r.path+data.Id())
+	HasFailed(d, err)
+}
+
+// plannedUnknown reports whether value of top-level attribute key is unknown in the plan being applied.
+func plannedUnknown(data *schema.ResourceData, key string) bool {
+	plan := data.GetRawPlan()
+	return !plan.IsNull() && plan.IsKnown() && !plan.GetAttr(key).IsKnown()
+}
+
+// expireTimeRemoved reports whether expiration, which is not managed by rotation block,
+// was removed from the configuration.
+func expireTimeRemoved(diff *schema.ResourceDiff) bool {
+	if len(diff.Get(rotationKey).([]any)) > 0 || diff.Get(expireTimeKey).(string) == "" {
+		return false
+	}
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	configured := rawConfig.GetAttr(expireTimeKey)
+	return configured.IsKnown() && configured.IsNull()
+}
+
+// rotationDue reports whether the credential expires within rotate_before of the rotation block.
+// Credential without expiration, which got rotation block later, is rotated right away,
+// so it gets expiration given by validity.
+func rotationDue(rotation any, expireTime string) bool {
+	rotateBefore, ok := rotationValue(rotation, rotateBeforeKey)
+	if !ok {
+		return false
+	}
+	if expireTime == "" {
+		return true
+	}
+	expire, err := time.Parse(time.RFC3339, expireTime)
+	if err != nil {
+		return false
+	}
+	return !time.Now().Add(rotateBefore).Before(expire)
+}
+
+func previousExpired(deleteTime string) bool {
+	if deleteTime == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, deleteTime)
+	return err == nil && !time.Now().Before(t)
+}
+
+// rotationValue returns duration stored under key of the rotation block, if the block is set.
+func rotationValue(rotation any, key string) (time.Duration, bool) {
+	list, _ := rotation.([]any)
+	if len(list) == 0 || list[0] == nil {
+		return 0, false
+	}
+	value, _ := list[0].(map[string]any)[key].(string)
+	dur, err := time.ParseDuration(value)
+	return dur, err == nil
+}
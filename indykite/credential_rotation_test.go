@@ -0,0 +1,442 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential rotation", func() {
+	const (
+		typeName = "indykite_service_account_credential"
+		newID    = "gid:AAAABnNlcnZpY2VBY2NvdW50Q3JlZB"
+	)
+	var (
+		mockServer  *httptest.Server
+		server      tfprotov6.ProviderServer
+		schemas     *tfprotov6.GetProviderSchemaResponse
+		credentials map[string]indykite.ServiceAccountCredentialResponse
+		nextIDs     []string
+		deleted     []string
+		failDelete  bool
+		ctx         = context.Background()
+	)
+
+	BeforeEach(func() {
+		credentials = map[string]indykite.ServiceAccountCredentialResponse{}
+		nextIDs = []string{serviceAccountCredID, newID}
+		deleted = nil
+		failDelete = false
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := strings.TrimPrefix(r.URL.Path, "/configs/v1/service-account-credentials/")
+			switch r.Method {
+			case http.MethodPost:
+				var req indykite.CreateServiceAccountCredentialRequest
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				cred := indykite.ServiceAccountCredentialResponse{
+					ID: nextIDs[0], Kid: "kid-" + nextIDs[0], ServiceAccountID: req.ServiceAccountID,
+					OrganizationID: organizationID, ExpireTime: req.ExpireTime, CreateTime: time.Now(),
+				}
+				nextIDs = nextIDs[1:]
+				credentials[cred.ID] = cred
+				cred.ServiceAccountConfig = `{"kid":"` + cred.Kid + `"}`
+				_ = json.NewEncoder(w).Encode(cred)
+			case http.MethodGet:
+				cred, ok := credentials[id]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(cred)
+			case http.MethodDelete:
+				if failDelete {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"code":7,"message":"permission denied"}`))
+					return
+				}
+				deleted = append(deleted, id)
+				delete(credentials, id)
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}))
+		server, schemas = configuredMuxServer(mockServer)
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	typ := func() tftypes.Type {
+		return schemas.ResourceSchemas[typeName].ValueType()
+	}
+	dynamicValue := func(value map[string]any) *tfprotov6.DynamicValue {
+		return resourceDynamicValue(schemas, typeName, value)
+	}
+	apply := func(prior, config map[string]any) map[string]any {
+		return planAndApply(server, schemas, typeName, prior, config)
+	}
+
+	It("creates new credential before deleting the old one on trigger change", func() {
+		state := apply(nil, map[string]any{"service_account_id": serviceAccountID, "rotation_trigger": "1"})
+		Expect(state).To(HaveKeyWithValue("id", serviceAccountCredID))
+		Expect(state).To(HaveKeyWithValue("service_account_config", ContainSubstring("kid-"+serviceAccountCredID)))
+
+		state = apply(state, map[string]any{"service_account_id": serviceAccountID, "rotation_trigger": "2"})
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("kid", "kid-"+newID))
+		Expect(state).To(HaveKeyWithValue("service_account_config", ContainSubstring("kid-"+newID)))
+		Expect(state).To(HaveKeyWithValue("previous_id", BeEmpty()))
+		Expect(deleted).To(ConsistOf(serviceAccountCredID))
+	})
+
+	It("keeps previous credential for the next apply when its deletion fails", func() {
+		config := map[string]any{"service_account_id": serviceAccountID, "rotation_trigger": "1"}
+		state := apply(nil, config)
+
+		failDelete = true
+		config["rotation_trigger"] = "2"
+		proposed := maps.Clone(state)
+		maps.Copy(proposed, config)
+		planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), ProposedNewState: dynamicValue(proposed),
+			Config: dynamicValue(config),
+		})
+		Expect(err).To(Succeed())
+		Expect(planResp.Diagnostics).To(BeEmpty())
+		applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), PlannedState: planResp.PlannedState,
+			Config: dynamicValue(config),
+		})
+		Expect(err).To(Succeed())
+		Expect(applyResp.Diagnostics).To(ConsistOf(And(
+			HaveField("Severity", tfprotov6.DiagnosticSeverityWarning),
+			HaveField("Summary", "Previous credential was not deleted"),
+		)))
+		value, err := applyResp.NewState.Unmarshal(typ())
+		Expect(err).To(Succeed())
+		state = indykite.StateValue(value).(map[string]any)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("service_account_config", ContainSubstring("kid-"+newID)))
+		Expect(state).To(HaveKeyWithValue("previous_id", serviceAccountCredID))
+		deleteTime, err := time.Parse(time.RFC3339, state["previous_delete_time"].(string))
+		Expect(err).To(Succeed())
+		Expect(deleteTime).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(deleted).To(BeEmpty())
+
+		failDelete = false
+		state = apply(state, config)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("previous_id", BeEmpty()))
+		Expect(deleted).To(ConsistOf(serviceAccountCredID))
+	})
+
+	It("rotates credential without expiration when rotation block is added", func() {
+		config := map[string]any{"service_account_id": serviceAccountID}
+		state := apply(nil, config)
+		Expect(state).To(HaveKeyWithValue("expire_time", BeNil()))
+
+		config["rotation"] = []any{map[string]any{"validity": "1h", "rotate_before": "10m"}}
+		state = apply(state, config)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("previous_id", serviceAccountCredID))
+		expireTime, err := time.Parse(time.RFC3339, state["expire_time"].(string))
+		Expect(err).To(Succeed())
+		Expect(expireTime).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	It("keeps previous credential during overlap when it is about to expire", func() {
+		config := map[string]any{
+			"service_account_id": serviceAccountID,
+			"rotation":           []any{map[string]any{"validity": "1h", "rotate_before": "2h"}},
+		}
+		state := apply(nil, config)
+		Expect(state).To(HaveKeyWithValue("id", serviceAccountCredID))
+		expireTime, err := time.Parse(time.RFC3339, state["expire_time"].(string))
+		Expect(err).To(Succeed())
+		Expect(expireTime).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+		state = apply(state, config)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("previous_id", serviceAccountCredID))
+		Expect(state).To(HaveKeyWithValue("previous_kid", "kid-"+serviceAccountCredID))
+		Expect(state).To(HaveKeyWithValue("previous_service_account_config",
+			ContainSubstring("kid-"+serviceAccountCredID)))
+		deleteTime, err := time.Parse(time.RFC3339, state["previous_delete_time"].(string))
+		Expect(err).To(Succeed())
+		Expect(deleteTime).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+		Expect(deleted).To(BeEmpty())
+
+		// Overlap window ended, and the new credential is not due yet
+		config["rotation"] = []any{map[string]any{"validity": "1h", "rotate_before": "10m"}}
+		state["previous_delete_time"] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		state = apply(state, config)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("previous_id", BeEmpty()))
+		Expect(deleted).To(ConsistOf(serviceAccountCredID))
+
+		// Nothing else to do until the new credential is due
+		resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), ProposedNewState: dynamicValue(state),
+			Config: dynamicValue(config),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		planned, err := resp.PlannedState.Unmarshal(typ())
+		Expect(err).To(Succeed())
+		Expect(indykite.StateValue(planned)).To(Equal(state))
+	})
+
+	It("applies only rotation decided by the plan", func() {
+		config := map[string]any{
+			"service_account_id": serviceAccountID,
+			"rotation":           []any{map[string]any{"validity": "1h", "rotate_before": "10m"}},
+		}
+		state := apply(nil, config)
+
+		// Plan was made when rotation was not due yet, clock at apply must not change it
+		config["rotation"] = []any{map[string]any{"validity": "1h", "rotate_before": "2h"}}
+		planned := maps.Clone(state)
+		planned["rotation"] = config["rotation"]
+		resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), PlannedState: dynamicValue(planned),
+			Config: dynamicValue(config),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		value, err := resp.NewState.Unmarshal(typ())
+		Expect(err).To(Succeed())
+		Expect(indykite.StateValue(value)).To(HaveKeyWithValue("id", serviceAccountCredID))
+		Expect(indykite.StateValue(value)).To(HaveKeyWithValue("kid", "kid-"+serviceAccountCredID))
+		Expect(nextIDs).To(ConsistOf(newID))
+	})
+
+	It("replaces credential when configured expiration is removed", func() {
+		state := apply(nil, map[string]any{
+			"service_account_id": serviceAccountID,
+			"expire_time":        time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})
+
+		resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), ProposedNewState: dynamicValue(state),
+			Config: dynamicValue(map[string]any{"service_account_id": serviceAccountID}),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(resp.RequiresReplace).To(ContainElement(tftypes.NewAttributePath().WithAttributeName("expire_time")))
+	})
+
+	It("replaces credential when configured expiration changes", func() {
+		state := apply(nil, map[string]any{
+			"service_account_id": serviceAccountID,
+			"expire_time":        time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})
+		proposed := maps.Clone(state)
+		proposed["expire_time"] = time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+		resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: dynamicValue(state), ProposedNewState: dynamicValue(proposed),
+			Config: dynamicValue(map[string]any{
+				"service_account_id": serviceAccountID, "expire_time": proposed["expire_time"],
+			}),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(resp.RequiresReplace).To(ContainElement(tftypes.NewAttributePath().WithAttributeName("expire_time")))
+	})
+})
+
+var _ = Describe("Credential rotation of application agent credential", func() {
+	const (
+		typeName = "indykite_application_agent_credential"
+		newID    = "gid:AAAAEmFwcEFnZW50Q3JlZGVudGlhbB"
+	)
+	var (
+		mockServer *httptest.Server
+		server     tfprotov6.ProviderServer
+		schemas    *tfprotov6.GetProviderSchemaResponse
+		nextIDs    []string
+		deleted    []string
+	)
+
+	BeforeEach(func() {
+		DeferCleanup(indykite.SetCredCreateWaits(time.Millisecond, time.Millisecond, time.Millisecond))
+		credentials := map[string]indykite.ApplicationAgentCredentialResponse{}
+		nextIDs = []string{appAgentCredID, newID}
+		deleted = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := strings.TrimPrefix(r.URL.Path, "/configs/v1/application-agent-credentials/")
+			switch r.Method {
+			case http.MethodPost:
+				var req indykite.CreateApplicationAgentCredentialRequest
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				cred := indykite.ApplicationAgentCredentialResponse{
+					ID: nextIDs[0], Kid: "kid-" + nextIDs[0], CustomerID: customerID, AppSpaceID: appSpaceID,
+					ApplicationID: applicationID, ApplicationAgentID: req.ApplicationAgentID, CreateTime: time.Now(),
+				}
+				cred.ExpireTime, _ = time.Parse(time.RFC3339, req.ExpireTime)
+				nextIDs = nextIDs[1:]
+				credentials[cred.ID] = cred
+				cred.AgentConfig = json.RawMessage(`{"kid":"` + cred.Kid + `"}`)
+				_ = json.NewEncoder(w).Encode(cred)
+			case http.MethodGet:
+				cred, ok := credentials[id]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(cred)
+			case http.MethodDelete:
+				deleted = append(deleted, id)
+				delete(credentials, id)
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}))
+		server, schemas = configuredMuxServer(mockServer)
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	It("rotates credential and rewrites local private key file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "agent.json")
+		config := map[string]any{
+			"app_agent_id":     appAgentID,
+			"private_key_file": path,
+			"rotation":         []any{map[string]any{"validity": "1h", "rotate_before": "2h"}},
+		}
+		state := planAndApply(server, schemas, typeName, nil, config)
+		Expect(state).To(HaveKeyWithValue("id", appAgentCredID))
+		Expect(os.ReadFile(path)).To(ContainSubstring(`"kid":"kid-` + appAgentCredID + `"`))
+
+		state = planAndApply(server, schemas, typeName, state, config)
+		Expect(state).To(HaveKeyWithValue("id", newID))
+		Expect(state).To(HaveKeyWithValue("kid", "kid-"+newID))
+		Expect(state).To(HaveKeyWithValue("agent_config", ContainSubstring("kid-"+newID)))
+		Expect(state).To(HaveKeyWithValue("previous_id", appAgentCredID))
+		Expect(state).To(HaveKeyWithValue("previous_agent_config", ContainSubstring("kid-"+appAgentCredID)))
+		Expect(os.ReadFile(path)).To(ContainSubstring(`"kid":"kid-` + newID + `"`))
+		Expect(deleted).To(BeEmpty())
+
+		// Trigger rotates again within the overlap window, so the previous credential is deleted
+		config["rotation_trigger"] = "1"
+		Expect(nextIDs).To(BeEmpty())
+		nextIDs = []string{appAgentCredID}
+		state = planAndApply(server, schemas, typeName, state, config)
+		Expect(state).To(HaveKeyWithValue("id", appAgentCredID))
+		Expect(state).To(HaveKeyWithValue("previous_id", newID))
+		Expect(deleted).To(ConsistOf(appAgentCredID))
+	})
+
+	It("deletes rotated credential when private key file cannot be written", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "keys")
+		Expect(os.Mkdir(dir, 0o700)).To(Succeed())
+		config := map[string]any{
+			"app_agent_id": appAgentID, "private_key_file": filepath.Join(dir, "agent.json"), "rotation_trigger": "1",
+		}
+		state := planAndApply(server, schemas, typeName, nil, config)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+
+		config["rotation_trigger"] = "2"
+		proposed := maps.Clone(state)
+		maps.Copy(proposed, config)
+		planResp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: resourceDynamicValue(schemas, typeName, state),
+			ProposedNewState: resourceDynamicValue(schemas, typeName, proposed),
+			Config:           resourceDynamicValue(schemas, typeName, config),
+		})
+		Expect(err).To(Succeed())
+		Expect(planResp.Diagnostics).To(BeEmpty())
+		applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: resourceDynamicValue(schemas, typeName, state),
+			PlannedState: planResp.PlannedState, Config: resourceDynamicValue(schemas, typeName, config),
+		})
+		Expect(err).To(Succeed())
+		Expect(applyResp.Diagnostics).To(HaveLen(1))
+		Expect(applyResp.Diagnostics[0].Summary).To(ContainSubstring("failed to write private_key_file"))
+		Expect(deleted).To(ConsistOf(newID))
+	})
+})
+
+// resourceDynamicValue converts plain Go value into resource value of given type, missing attributes are null.
+func resourceDynamicValue(
+	schemas *tfprotov6.GetProviderSchemaResponse,
+	typeName string,
+	value map[string]any,
+) *tfprotov6.DynamicValue {
+	typ := schemas.ResourceSchemas[typeName].ValueType()
+	dv, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, value))
+	Expect(err).To(Succeed())
+	return &dv
+}
+
+// planAndApply plans and applies config over prior state, which is nil on create, and returns the new state.
+// Plan must update the resource in place.
+func planAndApply(
+	server tfprotov6.ProviderServer,
+	schemas *tfprotov6.GetProviderSchemaResponse,
+	typeName string,
+	prior, config map[string]any,
+) map[string]any {
+	ctx := context.Background()
+	typ := schemas.ResourceSchemas[typeName].ValueType()
+	priorValue := resourceDynamicValue(schemas, typeName, prior)
+	if prior == nil {
+		dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+		Expect(err).To(Succeed())
+		priorValue = &dv
+	}
+	proposed := maps.Clone(prior)
+	if proposed == nil {
+		proposed = map[string]any{}
+	}
+	maps.Copy(proposed, config)
+
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName: typeName, PriorState: priorValue, ProposedNewState: resourceDynamicValue(schemas, typeName, proposed),
+		Config: resourceDynamicValue(schemas, typeName, config),
+	})
+	Expect(err).To(Succeed())
+	Expect(planResp.Diagnostics).To(BeEmpty())
+	if prior != nil {
+		Expect(planResp.RequiresReplace).To(BeEmpty())
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName: typeName, PriorState: priorValue, PlannedState: planResp.PlannedState,
+		Config: resourceDynamicValue(schemas, typeName, config),
+	})
+	Expect(err).To(Succeed())
+	Expect(applyResp.Diagnostics).To(BeEmpty())
+	value, err := applyResp.NewState.Unmarshal(typ)
+	Expect(err).To(Succeed())
+	return indykite.StateValue(value).(map[string]any)
+}
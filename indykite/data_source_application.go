@@ -17,7 +17,7 @@ package indykite
 import (
 	"context"
 	"fmt"
-	"strings"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -47,27 +47,29 @@ func dataSourceApplication() *schema.Resource {
 }
 
 func dataSourceApplicationList() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataApplicationListContext,
-		Schema: map[string]*schema.Schema{
-			appSpaceIDKey: appSpaceIDSchema(), // User-facing field - ONLY this should be in configs
-			filterKey:     exactNameFilterSchema(),
-			"applications": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						customerIDKey:  setComputed(customerIDSchema()),
-						appSpaceIDKey:  setComputed(appSpaceIDSchema()),
-						"id":           setComputed(applicationIDSchema()),
-						nameKey:        nameSchema(),
-						displayNameKey: displayNameSchema(),
-						descriptionKey: descriptionSchema(),
-					},
+	dataSchema := map[string]*schema.Schema{
+		appSpaceIDKey: appSpaceIDSchema(), // User-facing field - ONLY this should be in configs
+		maxResultsKey: maxResultsSchema(),
+		"applications": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					customerIDKey:  setComputed(customerIDSchema()),
+					appSpaceIDKey:  setComputed(appSpaceIDSchema()),
+					"id":           setComputed(applicationIDSchema()),
+					nameKey:        nameSchema(),
+					displayNameKey: displayNameSchema(),
+					descriptionKey: descriptionSchema(),
 				},
 			},
 		},
-		Timeouts: defaultDataTimeouts(),
+	}
+	maps.Copy(dataSchema, nameFilterSchemas())
+	return &schema.Resource{
+		ReadContext: dataApplicationListContext,
+		Schema:      dataSchema,
+		Timeouts:    defaultDataTimeouts(),
 	}
 }
 
@@ -142,11 +144,7 @@ func dataApplicationReadContext(ctx context.Context, data *schema.ResourceData,
 
 func dataApplicationListContext(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
-	rawFilter := data.Get(filterKey).([]any)
-	match := make([]string, len(rawFilter))
-	for i, v := range rawFilter {
-		match[i] = v.(string)
-	}
+	filter := buildNameFilter(data)
 
 	clientCtx := getClientContext(&d, meta)
 	if d.HasError() {
@@ -158,39 +156,29 @@ func dataApplicationListContext(ctx context.Context, data *schema.ResourceData,
 	// User provides app_space_id in config, we use project_id parameter for REST API
 	appSpaceID := data.Get(appSpaceIDKey).(string)
 
-	var resp ListResponse[ApplicationResponse]
-	err := clientCtx.GetClient().Get(ctx, "/applications?project_id="+appSpaceID+"&search=", &resp)
-	if HasFailed(&d, err) {
-		return d
-	}
-
-	allApplications := make([]map[string]any, 0, len(resp.Data))
-	for i := range resp.Data {
-		app := &resp.Data[i]
-		// Apply exact name match filter (MinItems: 1 ensures filter is always present)
-		matchFound := false
-		for _, filter := range match {
-			if app.Name == filter {
-				matchFound = true
-				break
-			}
-		}
-		if !matchFound {
-			continue
-		}
-
-		allApplications = append(allApplications, map[string]any{
+	maxResults := data.Get(maxResultsKey).(int)
+	allApplications := []map[string]any{}
+	path := "/applications?project_id=" + appSpaceID + "&search="
+	err := listAllPages(ctx, clientCtx.GetClient(), path, func(app *ApplicationResponse) bool {
+		entry := map[string]any{
 			customerIDKey:  app.CustomerID,
 			appSpaceIDKey:  app.AppSpaceID,
 			"id":           app.ID,
 			nameKey:        app.Name,
 			displayNameKey: app.DisplayName,
 			descriptionKey: app.Description,
-		})
+		}
+		if !filter.matches(entry) {
+			return true
+		}
+		allApplications = append(allApplications, entry)
+		return maxResults == 0 || len(allApplications) < maxResults
+	})
+	if listHasFailed(&d, err) {
+		return d
 	}
 	setData(&d, data, "applications", allApplications)
 
-	id := appSpaceID + "/apps/" + strings.Join(match, ",")
-	data.SetId(id)
+	data.SetId(appSpaceID + "/apps" + filter.idSuffix())
 	return d
 }
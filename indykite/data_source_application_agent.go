@@ -17,7 +17,7 @@ package indykite
 import (
 	"context"
 	"fmt"
-	"strings"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -49,29 +49,31 @@ func dataSourceAppAgent() *schema.Resource {
 }
 
 func dataSourceAppAgentList() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataAppAgentListContext,
-		Schema: map[string]*schema.Schema{
-			appSpaceIDKey: appSpaceIDSchema(), // User-facing field - ONLY this should be in configs
-			filterKey:     exactNameFilterSchema(),
-			"app_agents": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						customerIDKey:     setComputed(customerIDSchema()),
-						appSpaceIDKey:     setComputed(appSpaceIDSchema()),
-						applicationIDKey:  setComputed(applicationIDSchema()),
-						"id":              setComputed(appAgentIDSchema()),
-						nameKey:           nameSchema(),
-						displayNameKey:    displayNameSchema(),
-						descriptionKey:    descriptionSchema(),
-						apiPermissionsKey: apiPermissionsSchema(),
-					},
+	dataSchema := map[string]*schema.Schema{
+		appSpaceIDKey: appSpaceIDSchema(), // User-facing field - ONLY this should be in configs
+		maxResultsKey: maxResultsSchema(),
+		"app_agents": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					customerIDKey:     setComputed(customerIDSchema()),
+					appSpaceIDKey:     setComputed(appSpaceIDSchema()),
+					applicationIDKey:  setComputed(applicationIDSchema()),
+					"id":              setComputed(appAgentIDSchema()),
+					nameKey:           nameSchema(),
+					displayNameKey:    displayNameSchema(),
+					descriptionKey:    descriptionSchema(),
+					apiPermissionsKey: apiPermissionsSchema(),
 				},
 			},
 		},
-		Timeouts: defaultDataTimeouts(),
+	}
+	maps.Copy(dataSchema, nameFilterSchemas())
+	return &schema.Resource{
+		ReadContext: dataAppAgentListContext,
+		Schema:      dataSchema,
+		Timeouts:    defaultDataTimeouts(),
 	}
 }
 
@@ -152,11 +154,7 @@ func dataAppAgentReadContext(ctx context.Context, data *schema.ResourceData, met
 
 func dataAppAgentListContext(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
-	rawFilter := data.Get(filterKey).([]any)
-	match := make([]string, len(rawFilter))
-	for i, v := range rawFilter {
-		match[i] = v.(string)
-	}
+	filter := buildNameFilter(data)
 
 	clientCtx := getClientContext(&d, meta)
 	if d.HasError() {
@@ -168,28 +166,11 @@ func dataAppAgentListContext(ctx context.Context, data *schema.ResourceData, met
 	// User provides app_space_id in config, we use project_id parameter for REST API
 	appSpaceID := data.Get(appSpaceIDKey).(string)
 
-	var resp ListResponse[ApplicationAgentResponse]
-	err := clientCtx.GetClient().Get(ctx, "/application-agents?project_id="+appSpaceID, &resp)
-	if HasFailed(&d, err) {
-		return d
-	}
-
-	allApplicationAgents := make([]map[string]any, 0, len(resp.Data))
-	for i := range resp.Data {
-		agent := &resp.Data[i]
-		// Apply exact name match filter (MinItems: 1 ensures filter is always present)
-		matchFound := false
-		for _, filter := range match {
-			if agent.Name == filter {
-				matchFound = true
-				break
-			}
-		}
-		if !matchFound {
-			continue
-		}
-
-		allApplicationAgents = append(allApplicationAgents, map[string]any{
+	maxResults := data.Get(maxResultsKey).(int)
+	allApplicationAgents := []map[string]any{}
+	path := "/application-agents?project_id=" + appSpaceID
+	err := listAllPages(ctx, clientCtx.GetClient(), path, func(agent *ApplicationAgentResponse) bool {
+		entry := map[string]any{
 			customerIDKey:     agent.CustomerID,
 			appSpaceIDKey:     agent.AppSpaceID,
 			applicationIDKey:  agent.ApplicationID,
@@ -198,11 +179,18 @@ func dataAppAgentListContext(ctx context.Context, data *schema.ResourceData, met
 			displayNameKey:    agent.DisplayName,
 			descriptionKey:    agent.Description,
 			apiPermissionsKey: agent.APIPermissions,
-		})
+		}
+		if !filter.matches(entry) {
+			return true
+		}
+		allApplicationAgents = append(allApplicationAgents, entry)
+		return maxResults == 0 || len(allApplicationAgents) < maxResults
+	})
+	if listHasFailed(&d, err) {
+		return d
 	}
 	setData(&d, data, "app_agents", allApplicationAgents)
 
-	id := appSpaceID + "/app_agents/" + strings.Join(match, ",")
-	data.SetId(id)
+	data.SetId(appSpaceID + "/app_agents" + filter.idSuffix())
 	return d
 }
@@ -40,6 +40,7 @@ func dataSourceAppAgentCredentialList() *schema.Resource {
 		listAttrName:    "app_agent_credentials",
 		fullFetch:       false,
 		withFilter:      false,
+		withExpiry:      true,
 		description:     "List Application Agent Credentials in the given Application Space.",
 		entrySchema:     entrySchema,
 		flatten: func(credential *ApplicationAgentCredentialResponse) map[string]any {
@@ -16,7 +16,7 @@ package indykite
 
 import (
 	"context"
-	"strings"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -50,31 +50,33 @@ func dataSourceAppSpace() *schema.Resource {
 }
 
 func dataSourceAppSpaceList() *schema.Resource {
-	return &schema.Resource{
-		ReadContext: dataAppSpaceListContext,
-		Schema: map[string]*schema.Schema{
-			customerIDKey: customerIDSchema(),
-			filterKey:     exactNameFilterSchema(),
-			"app_spaces": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						customerIDKey:    setComputed(customerIDSchema()),
-						"id":             setComputed(appSpaceIDSchema()),
-						nameKey:          nameSchema(),
-						displayNameKey:   displayNameSchema(),
-						descriptionKey:   descriptionSchema(),
-						regionKey:        setComputed(regionSchema()),
-						ikgSizeKey:       ikgSizeComputedSchema(),
-						replicaRegionKey: setComputed(replicaRegionSchema()),
-						// Note: db_connection is intentionally omitted from list view for security
-						// Users should query individual app spaces to get db connection details
-					},
+	dataSchema := map[string]*schema.Schema{
+		customerIDKey: customerIDSchema(),
+		maxResultsKey: maxResultsSchema(),
+		"app_spaces": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					customerIDKey:    setComputed(customerIDSchema()),
+					"id":             setComputed(appSpaceIDSchema()),
+					nameKey:          nameSchema(),
+					displayNameKey:   displayNameSchema(),
+					descriptionKey:   descriptionSchema(),
+					regionKey:        setComputed(regionSchema()),
+					ikgSizeKey:       ikgSizeComputedSchema(),
+					replicaRegionKey: setComputed(replicaRegionSchema()),
+					// Note: db_connection is intentionally omitted from list view for security
+					// Users should query individual app spaces to get db connection details
 				},
 			},
 		},
-		Timeouts: defaultDataTimeouts(),
+	}
+	maps.Copy(dataSchema, nameFilterSchemas())
+	return &schema.Resource{
+		ReadContext: dataAppSpaceListContext,
+		Schema:      dataSchema,
+		Timeouts:    defaultDataTimeouts(),
 	}
 }
 
@@ -130,11 +132,7 @@ func dataAppSpaceFlatten(data *schema.ResourceData, resp *ApplicationSpaceRespon
 
 func dataAppSpaceListContext(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
-	rawFilter := data.Get(filterKey).([]any)
-	match := make([]string, len(rawFilter))
-	for i, v := range rawFilter {
-		match[i] = v.(string)
-	}
+	filter := buildNameFilter(data)
 
 	clientCtx := getClientContext(&d, meta)
 	if d.HasError() {
@@ -144,28 +142,11 @@ func dataAppSpaceListContext(ctx context.Context, data *schema.ResourceData, met
 	defer cancel()
 
 	customerID := data.Get(customerIDKey).(string)
-	var resp ListResponse[ApplicationSpaceResponse]
-	err := clientCtx.GetClient().Get(ctx, "/projects?organization_id="+customerID, &resp)
-	if HasFailed(&d, err) {
-		return d
-	}
-
-	allAppSpaces := make([]map[string]any, 0, len(resp.Data))
-	for i := range resp.Data {
-		appSpace := &resp.Data[i]
-		// Apply exact name match filter (MinItems: 1 ensures filter is always present)
-		matchFound := false
-		for _, filter := range match {
-			if appSpace.Name == filter {
-				matchFound = true
-				break
-			}
-		}
-		if !matchFound {
-			continue
-		}
-
-		allAppSpaces = append(allAppSpaces, map[string]any{
+	maxResults := data.Get(maxResultsKey).(int)
+	allAppSpaces := []map[string]any{}
+	path := "/projects?organization_id=" + customerID
+	err := listAllPages(ctx, clientCtx.GetClient(), path, func(appSpace *ApplicationSpaceResponse) bool {
+		entry := map[string]any{
 			customerIDKey:    appSpace.CustomerID,
 			"id":             appSpace.ID,
 			nameKey:          appSpace.Name,
@@ -175,11 +156,18 @@ func dataAppSpaceListContext(ctx context.Context, data *schema.ResourceData, met
 			ikgSizeKey:       appSpace.IKGSize,
 			replicaRegionKey: appSpace.ReplicaRegion,
 			// db_connection intentionally omitted from list view for security
-		})
+		}
+		if !filter.matches(entry) {
+			return true
+		}
+		allAppSpaces = append(allAppSpaces, entry)
+		return maxResults == 0 || len(allAppSpaces) < maxResults
+	})
+	if listHasFailed(&d, err) {
+		return d
 	}
 	setData(&d, data, "app_spaces", allAppSpaces)
 
-	id := customerID + "/appSpaces/" + strings.Join(match, ",")
-	data.SetId(id)
+	data.SetId(customerID + "/appSpaces" + filter.idSuffix())
 	return d
 }
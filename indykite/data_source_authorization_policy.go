@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceAuthorizationPolicy() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceAuthorizationPolicy(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceAuthorizationPolicyList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[authzJSONConfigKey] = computedStringSchema("Policy document as JSON string")
@@ -36,8 +41,10 @@ func dataSourceAuthorizationPolicyList() *schema.Resource {
 		listAttrName:    "authorization_policies",
 		fullFetch:       true,
 		withFilter:      true,
-		description:     "List Authorization Policies in the given Application Space, filtered by exact name match.",
-		entrySchema:     entrySchema,
+		withTags:        true,
+		description: "List Authorization Policies in the given Application Space, " +
+			"optionally filtered by name or tags.",
+		entrySchema: entrySchema,
 		flatten: func(policy *AuthorizationPolicyResponse) map[string]any {
 			return map[string]any{
 				"id":               policy.ID,
@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceEntityMatchingPipeline() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceEntityMatchingPipeline(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceEntityMatchingPipelineList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[entityMatchingPipelineSimilarityScoreCutOffKey] = &schema.Schema{
@@ -35,7 +40,7 @@ func dataSourceEntityMatchingPipelineList() *schema.Resource {
 		fullFetch:       true,
 		withFilter:      true,
 		description: "List Entity Matching Pipelines in the given Application Space, " +
-			"filtered by exact name match.",
+			"optionally filtered by name.",
 		entrySchema: entrySchema,
 		flatten: func(pipeline *EntityMatchingPipelineResponse) map[string]any {
 			return map[string]any{
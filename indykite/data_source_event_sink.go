@@ -15,9 +15,139 @@
 package indykite
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// eventSinkDataSource is the framework variant of restSingleDataSource for the event sink.
+type eventSinkDataSource struct {
+	clientCtx *ClientContext
+}
+
+var _ datasource.DataSourceWithConfigure = &eventSinkDataSource{}
+
+func newEventSinkDataSource() datasource.DataSource {
+	return &eventSinkDataSource{}
+}
+
+func (*eventSinkDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_event_sink"
+}
+
+func (*eventSinkDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	var resourceSchema resource.SchemaResponse
+	(&eventSinkResource{}).Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+
+	attributes := computedDataSourceAttributes(resourceSchema.Schema.Attributes, resourceSchema.Schema.Blocks)
+	attributes[dataSourceIDKey] = dsschema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Identifier of the resource. Either this or `name` must be specified.",
+		Validators: []validator.String{
+			gidValidator(),
+			stringvalidator.ExactlyOneOf(path.MatchRoot(nameKey)),
+		},
+	}
+	attributes[nameKey] = dsschema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: nameDescription,
+		Validators: []validator.String{
+			nameValidator(),
+			stringvalidator.AlsoRequires(path.MatchRoot(locationKey)),
+		},
+	}
+	attributes[locationKey] = dsschema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: locationDescription,
+		Validators: []validator.String{
+			gidValidator(),
+			stringvalidator.ConflictsWith(path.MatchRoot(dataSourceIDKey)),
+		},
+	}
+
+	resp.Schema = dsschema.Schema{
+		Description: resourceSchema.Schema.Description,
+		Attributes:  attributes,
+		Blocks:      map[string]dsschema.Block{timeoutsKey: dataSourceTimeoutsBlock()},
+	}
+}
+
+func (d *eventSinkDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Provider is not configured yet during validation
+	if req.ProviderData == nil {
+		return
+	}
+	d.clientCtx = frameworkClientContext(&resp.Diagnostics, req.ProviderData)
+}
+
+func (d *eventSinkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Data source has no write-only attributes of the resource model, so only lookup keys are read
+	var config eventSinkModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(dataSourceIDKey), &config.ID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(nameKey), &config.Name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(locationKey), &config.Location)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(timeoutsKey), &config.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, operationTimeout(config.Timeouts, timeoutReadKey))
+	defer cancel()
+
+	lookupID := config.ID.ValueString()
+	if config.ID.IsNull() {
+		lookupID = config.Name.ValueString() + "?location=" + config.Location.ValueString()
+	}
+
+	state := readEventSink(ctx, &resp.Diagnostics, d.clientCtx, lookupID, &config)
+	if state == nil {
+		// Resource read only warns when the entry is gone, data source must fail instead
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError("Data source lookup did not find any entry", "No entry found for "+lookupID)
+		}
+		return
+	}
+
+	// Model is shaped by the resource schema, timeouts of the data source are set back after the projection
+	var resourceSchema resource.SchemaResponse
+	(&eventSinkResource{}).Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	timeoutsType, diags := resourceSchema.Schema.TypeAtPath(ctx, path.Root(timeoutsKey))
+	resp.Diagnostics.Append(diags...)
+	if objectType, ok := timeoutsType.(types.ObjectType); ok {
+		state.Timeouts = types.ObjectNull(objectType.AttrTypes)
+	}
+	resourceState := tfsdk.State{Schema: resourceSchema.Schema}
+	resp.Diagnostics.Append(resourceState.Set(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	raw, err := projectValue(resourceState.Raw, resp.State.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot convert event sink into data source state", err.Error())
+		return
+	}
+	resp.State.Raw = raw
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(timeoutsKey), config.Timeouts)...)
+}
+
 func dataSourceEventSinkList() *schema.Resource {
 	// Note: provider/route configuration is intentionally omitted from the
 	// list view because providers carry credentials (passwords, access keys).
@@ -28,7 +158,7 @@ func dataSourceEventSinkList() *schema.Resource {
 		listAttrName:    "event_sinks",
 		fullFetch:       false,
 		withFilter:      true,
-		description:     "List Event Sinks in the given Application Space, filtered by exact name match.",
+		description:     "List Event Sinks in the given Application Space, optionally filtered by name.",
 		entrySchema:     listEntryCommonSchema(true),
 		flatten: func(sink *EventSinkResponse) map[string]any {
 			return map[string]any{
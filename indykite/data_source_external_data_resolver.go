@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceExternalDataResolver() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceExternalDataResolver(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceExternalDataResolverList() *schema.Resource {
 	// Note: headers and request_payload are intentionally omitted from the
 	// list view because they commonly carry credentials (e.g. Authorization).
@@ -35,7 +40,7 @@ func dataSourceExternalDataResolverList() *schema.Resource {
 		listAttrName:    "external_data_resolvers",
 		fullFetch:       true,
 		withFilter:      true,
-		description:     "List External Data Resolvers in the given Application Space, filtered by exact name match.",
+		description:     "List External Data Resolvers in the given Application Space, optionally filtered by name.",
 		entrySchema:     entrySchema,
 		flatten: func(resolver *ExternalDataResolverResponse) map[string]any {
 			return map[string]any{
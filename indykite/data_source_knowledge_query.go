@@ -18,6 +18,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceKnowledgeQuery() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceKnowledgeQuery(), scopeKey: locationKey}
+	dataSource := single.dataSource()
+	// Affects only plans of the resource, meaningless for data sources
+	delete(dataSource.Schema, knowledgeQuerySkipPolicyCheckKey)
+	return dataSource
+}
+
 func dataSourceKnowledgeQueryList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[knowledgeQueryJSONQueryConfigKey] = computedStringSchema("Query document as JSON string")
@@ -32,7 +40,7 @@ func dataSourceKnowledgeQueryList() *schema.Resource {
 		listAttrName:    "knowledge_queries",
 		fullFetch:       true,
 		withFilter:      true,
-		description:     "List Knowledge Queries in the given Application Space, filtered by exact name match.",
+		description:     "List Knowledge Queries in the given Application Space, optionally filtered by name.",
 		entrySchema:     entrySchema,
 		flatten: func(query *KnowledgeQueryResponse) map[string]any {
 			return map[string]any{
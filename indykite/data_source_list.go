@@ -16,16 +16,31 @@ package indykite
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	maxResultsKey = "max_results"
+	namePrefixKey = "name_prefix"
+	nameRegexKey  = "name_regex"
+	tagsFilterKey = "tags"
 )
 
 // restListDataSource describes a data source listing one Config API collection.
 // All list endpoints share the same shape: GET {path}?{scopeQueryParam}={scope GID}
-// returning {"data": [...]}; entries are optionally filtered client-side by exact name match.
+// returning {"data": [...], "next_page_token": "..."}; all pages are fetched and entries
+// are optionally filtered client-side by exact name, name prefix, name regex and tags.
 type restListDataSource[T any] struct {
 	entrySchema map[string]*schema.Schema
 	// flatten converts one API response entry into a listAttrName element;
@@ -44,9 +59,14 @@ type restListDataSource[T any] struct {
 	// fullFetch requests full configuration instead of metadata only;
 	// required when entrySchema exposes fields stored in the configuration.
 	fullFetch bool
-	// withFilter enables the required exact-name filter;
+	// withFilter enables the optional name filters (exact names, prefix and regex);
 	// disabled for credentials, which have no name to match on.
 	withFilter bool
+	// withTags enables the tags filter, matched against authzTagsKey of flattened entries.
+	withTags bool
+	// withExpiry enables expiring_within filter and expiration warnings of credentials,
+	// matched against expireTimeKey of flattened entries.
+	withExpiry bool
 }
 
 func (l *restListDataSource[T]) dataSource() *schema.Resource {
@@ -61,9 +81,27 @@ func (l *restListDataSource[T]) dataSource() *schema.Resource {
 			Computed: true,
 			Elem:     &schema.Resource{Schema: l.entrySchema},
 		},
+		maxResultsKey: maxResultsSchema(),
 	}
 	if l.withFilter {
-		dataSchema[filterKey] = exactNameFilterSchema()
+		maps.Copy(dataSchema, nameFilterSchemas())
+	}
+	if l.withTags {
+		dataSchema[tagsFilterKey] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotEmpty},
+			Description: "Filter resources having all given tags.",
+		}
+	}
+	if l.withExpiry {
+		dataSchema[expiringWithinKey] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: ValidateDuration,
+			Description:      "Filter credentials expiring within given duration, like `720h`, including expired ones.",
+		}
 	}
 	return &schema.Resource{
 		Description: l.description,
@@ -75,14 +113,7 @@ func (l *restListDataSource[T]) dataSource() *schema.Resource {
 
 func (l *restListDataSource[T]) readContext(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
-	var match []string
-	if l.withFilter {
-		rawFilter := data.Get(filterKey).([]any)
-		match = make([]string, len(rawFilter))
-		for i, v := range rawFilter {
-			match[i] = v.(string)
-		}
-	}
+	filter := l.buildFilter(data)
 
 	clientCtx := getClientContext(&d, meta)
 	if d.HasError() {
@@ -92,43 +123,201 @@ func (l *restListDataSource[T]) readContext(ctx context.Context, data *schema.Re
 	defer cancel()
 
 	scopeID := data.Get(l.scopeKey).(string)
+	maxResults := data.Get(maxResultsKey).(int)
 	path := l.path + "?" + l.scopeQueryParam + "=" + scopeID
 	if l.fullFetch {
 		path += "&full_fetch=true"
 	}
-	var resp ListResponse[T]
-	err := clientCtx.GetClient().Get(ctx, path, &resp)
-	if HasFailed(&d, err) {
-		return d
-	}
 
-	entries := make([]map[string]any, 0, len(resp.Data))
-	for i := range resp.Data {
-		entry := l.flatten(&resp.Data[i])
-		if l.withFilter {
-			// Apply exact name match filter (MinItems: 1 ensures filter is always present)
-			name, _ := entry[nameKey].(string)
-			matchFound := false
-			for _, filter := range match {
-				if name == filter {
-					matchFound = true
-					break
-				}
-			}
-			if !matchFound {
-				continue
-			}
+	entries := []map[string]any{}
+	err := listAllPages(ctx, clientCtx.GetClient(), path, func(item *T) bool {
+		entry := l.flatten(item)
+		if !filter.matches(entry) {
+			return true
 		}
 		entries = append(entries, entry)
+		return maxResults == 0 || len(entries) < maxResults
+	})
+	if listHasFailed(&d, err) {
+		return d
+	}
+	if l.withExpiry {
+		for _, entry := range entries {
+			clientCtx.warnCredentialExpiry(&d, entry["id"].(string), entry[kidKey].(string),
+				parseExpireTime(entry[expireTimeKey].(string)))
+		}
 	}
 	setData(&d, data, l.listAttrName, entries)
 
-	id := scopeID + "/" + l.listAttrName
+	data.SetId(scopeID + "/" + l.listAttrName + filter.idSuffix())
+	return d
+}
+
+// listFilter holds client-side filters of list entries, all of them must match.
+type listFilter struct {
+	nameRegex  *regexp.Regexp
+	namePrefix string
+	names      []string
+	tags       []string
+	// expiringWithin is kept as configured for the ID, expiringBefore is computed from it.
+	expiringWithin string
+	expiringBefore time.Time
+}
+
+func (l *restListDataSource[T]) buildFilter(data *schema.ResourceData) *listFilter {
+	filter := &listFilter{}
 	if l.withFilter {
-		id += "/" + strings.Join(match, ",")
+		filter = buildNameFilter(data)
+	}
+	if l.withTags {
+		filter.tags = rawArrayToTypedArray[string](data.Get(tagsFilterKey).([]any))
+	}
+	if l.withExpiry {
+		filter.expiringWithin = data.Get(expiringWithinKey).(string)
+		// Validity of the duration is checked already by schema validation
+		if within, err := time.ParseDuration(filter.expiringWithin); err == nil {
+			filter.expiringBefore = time.Now().Add(within)
+		}
+	}
+	return filter
+}
+
+// nameFilterSchemas returns optional filters of list entries by exact names, name prefix and name regex.
+func nameFilterSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		filterKey: convertToOptional(exactNameFilterSchema()),
+		namePrefixKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Filter resources with name starting with given prefix.",
+		},
+		nameRegexKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+			Description:  "Filter resources with name matching given regular expression (RE2 syntax).",
+		},
+	}
+}
+
+// buildNameFilter returns listFilter with name filters of nameFilterSchemas set from data.
+func buildNameFilter(data *schema.ResourceData) *listFilter {
+	filter := &listFilter{
+		names:      rawArrayToTypedArray[string](data.Get(filterKey).([]any)),
+		namePrefix: data.Get(namePrefixKey).(string),
+	}
+	// Validity of the expression is checked already by schema validation
+	if expr := data.Get(nameRegexKey).(string); expr != "" {
+		filter.nameRegex = regexp.MustCompile(expr)
+	}
+	return filter
+}
+
+func (f *listFilter) matches(entry map[string]any) bool {
+	name, _ := entry[nameKey].(string)
+	switch {
+	case len(f.names) > 0 && !slices.Contains(f.names, name),
+		f.namePrefix != "" && !strings.HasPrefix(name, f.namePrefix),
+		f.nameRegex != nil && !f.nameRegex.MatchString(name):
+		return false
+	}
+	if !f.expiringBefore.IsZero() {
+		expireTime, _ := entry[expireTimeKey].(string)
+		if t := parseExpireTime(expireTime); t.IsZero() || t.After(f.expiringBefore) {
+			return false
+		}
+	}
+	entryTags, _ := entry[authzTagsKey].([]string)
+	for _, tag := range f.tags {
+		if !slices.Contains(entryTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// idSuffix returns the part of data source ID describing the filters, empty when listing everything.
+// Exact names are kept as the first segment for backward compatibility of the ID.
+func (f *listFilter) idSuffix() string {
+	var parts []string
+	if len(f.names) > 0 {
+		parts = append(parts, strings.Join(f.names, ","))
+	}
+	if f.namePrefix != "" {
+		parts = append(parts, namePrefixKey+"="+f.namePrefix)
+	}
+	if f.nameRegex != nil {
+		parts = append(parts, nameRegexKey+"="+f.nameRegex.String())
+	}
+	if len(f.tags) > 0 {
+		parts = append(parts, tagsFilterKey+"="+strings.Join(f.tags, ","))
+	}
+	if f.expiringWithin != "" {
+		parts = append(parts, expiringWithinKey+"="+f.expiringWithin)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// listAllPages fetches all pages of the collection at path, which must already contain a query string,
+// and calls visit for every entry until it returns false. All pages share the deadline of ctx,
+// which is the read timeout of the data source.
+func listAllPages[T any](ctx context.Context, client *RestClient, path string, visit func(item *T) bool) error {
+	pageToken := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pagePath := path
+		if pageToken != "" {
+			pagePath += "&page_token=" + url.QueryEscape(pageToken)
+		}
+
+		var resp ListResponse[T]
+		if err := client.Get(ctx, pagePath, &resp); err != nil {
+			return err
+		}
+		for i := range resp.Data {
+			if !visit(&resp.Data[i]) {
+				return nil
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		if resp.NextPageToken == pageToken {
+			return fmt.Errorf("listing %s returned the same page token %q twice", path, pageToken)
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// listHasFailed is HasFailed, which reports exceeded read timeout as user error with a hint how to fix it.
+func listHasFailed(d *diag.Diagnostics, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		*d = append(*d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Listing did not finish within the read timeout",
+			Detail: "Increase read timeout in timeouts block, or limit the number of entries with " +
+				maxResultsKey + ". " + err.Error(),
+		})
+		return true
+	}
+	return HasFailed(d, err)
+}
+
+func maxResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description: "Maximal number of entries to return. When not set, all pages are fetched " +
+			"until the collection is exhausted or the read timeout expires.",
 	}
-	data.SetId(id)
-	return d
 }
 
 func computedStringSchema(description string) *schema.Schema {
@@ -26,10 +26,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
 
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 )
 
 // listDataSourceTestCase describes one config-collection list data source test:
@@ -479,22 +481,30 @@ var _ = Describe("DataSource config collection lists", func() {
 				{
 					Config: `data "indykite_authorization_policies" "test" {
 						app_space_id = "` + appSpaceID + `"
+						filter = []
 					}`,
-					ExpectError: regexp.MustCompile(`The argument "filter" is required`),
+					ExpectError: regexp.MustCompile("Attribute filter requires 1 item minimum, but config has only 0"),
 				},
 				{
 					Config: `data "indykite_authorization_policies" "test" {
 						app_space_id = "` + appSpaceID + `"
-						filter = []
+						filter = [123]
 					}`,
-					ExpectError: regexp.MustCompile("Attribute filter requires 1 item minimum, but config has only 0"),
+					ExpectError: regexp.MustCompile("Value can have lowercase letters, digits, or hyphens."),
 				},
 				{
 					Config: `data "indykite_authorization_policies" "test" {
 						app_space_id = "` + appSpaceID + `"
-						filter = [123]
+						name_regex = "kq-("
 					}`,
-					ExpectError: regexp.MustCompile("Value can have lowercase letters, digits, or hyphens."),
+					ExpectError: regexp.MustCompile(`"name_regex": error parsing regexp`),
+				},
+				{
+					Config: `data "indykite_knowledge_queries" "test" {
+						app_space_id = "` + appSpaceID + `"
+						tags = ["team-payments"]
+					}`,
+					ExpectError: regexp.MustCompile(`An argument named "tags" is not expected here`),
 				},
 				{
 					Config: `data "indykite_service_accounts" "test" {
@@ -511,11 +521,10 @@ var _ = Describe("DataSource config collection lists", func() {
 					ExpectError: regexp.MustCompile(`An argument named "filter" is not expected here`),
 				},
 				{
-					// Valid configuration, but the mock returns 500 for everything:
+					// Valid configuration without any filter, but the mock returns 500 for everything:
 					// covers the API error path of the shared list readContext.
 					Config: `data "indykite_authorization_policies" "test" {
 						app_space_id = "` + appSpaceID + `"
-						filter = ["acme"]
 					}`,
 					ExpectError: regexp.MustCompile("Communication with IndyKite failed"),
 				},
@@ -523,3 +532,207 @@ var _ = Describe("DataSource config collection lists", func() {
 		})
 	})
 })
+
+var _ = Describe("DataSource config collection list reading", func() {
+	var (
+		mockServer *httptest.Server
+		meta       any
+		dataSource *schema.Resource
+		pageTokens []string
+		pages      map[string]indykite.ListResponse[indykite.AuthorizationPolicyResponse]
+	)
+
+	policies := func(names ...string) []indykite.AuthorizationPolicyResponse {
+		out := make([]indykite.AuthorizationPolicyResponse, len(names))
+		for i, name := range names {
+			out[i] = indykite.AuthorizationPolicyResponse{ID: sampleID, Name: name, AppSpaceID: appSpaceID}
+			if strings.HasPrefix(name, "name-") {
+				out[i].Tags = []string{"team-payments", name}
+			}
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		pageTokens = nil
+		pages = map[string]indykite.ListResponse[indykite.AuthorizationPolicyResponse]{
+			"":   {Data: policies("name-one", "other"), NextPageToken: "p2"},
+			"p2": {Data: policies("other", "name-two"), NextPageToken: "p3"},
+			"p3": {Data: policies("name-one")},
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get("page_token")
+			pageTokens = append(pageTokens, token)
+			Expect(r.URL.Query().Get("project_id")).To(Equal(appSpaceID))
+			_ = json.NewEncoder(w).Encode(pages[token])
+		}))
+
+		provider := indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		meta = provider.Meta()
+		dataSource = provider.DataSourcesMap["indykite_authorization_policies"]
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	read := func(raw map[string]any) (*schema.ResourceData, diag.Diagnostics) {
+		raw["app_space_id"] = appSpaceID
+		data := schema.TestResourceDataRaw(GinkgoT(), dataSource.Schema, raw)
+		return data, dataSource.ReadContext(context.Background(), data, meta)
+	}
+
+	It("fetches all pages", func() {
+		data, d := read(map[string]any{"filter": []any{"name-one", "name-two"}})
+		Expect(d).To(BeEmpty())
+		Expect(pageTokens).To(Equal([]string{"", "p2", "p3"}))
+		Expect(data.Get("authorization_policies.#")).To(Equal(3))
+		Expect(data.Get("authorization_policies.1.name")).To(Equal("name-two"))
+	})
+
+	It("stops after max_results entries", func() {
+		data, d := read(map[string]any{"filter": []any{"name-one", "name-two"}, "max_results": 2})
+		Expect(d).To(BeEmpty())
+		Expect(pageTokens).To(Equal([]string{"", "p2"}))
+		Expect(data.Get("authorization_policies.#")).To(Equal(2))
+	})
+
+	It("fails on repeated page token", func() {
+		pages["p3"] = indykite.ListResponse[indykite.AuthorizationPolicyResponse]{NextPageToken: "p3"}
+		_, d := read(map[string]any{})
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Summary).To(ContainSubstring(`returned the same page token "p3" twice`))
+	})
+
+	DescribeTable("filters entries",
+		func(raw map[string]any, expectedID string, expectedNames ...string) {
+			data, d := read(raw)
+			Expect(d).To(BeEmpty())
+			Expect(data.Id()).To(Equal(appSpaceID + "/authorization_policies" + expectedID))
+			names := []string{}
+			for _, entry := range data.Get("authorization_policies").([]any) {
+				names = append(names, entry.(map[string]any)["name"].(string))
+			}
+			Expect(names).To(Equal(expectedNames))
+		},
+		Entry("without filter", map[string]any{}, "", "name-one", "other", "other", "name-two", "name-one"),
+		Entry("by prefix", map[string]any{"name_prefix": "oth"}, "/name_prefix=oth", "other", "other"),
+		Entry("by regex", map[string]any{"name_regex": "-two$"}, "/name_regex=-two$", "name-two"),
+		Entry("by tags", map[string]any{"tags": []any{"team-payments", "name-one"}},
+			"/tags=team-payments,name-one", "name-one", "name-one"),
+		Entry("by all combined", map[string]any{
+			"filter":      []any{"name-one", "name-two"},
+			"name_prefix": "name-",
+			"name_regex":  "t",
+			"tags":        []any{"team-payments"},
+		}, "/name-one,name-two/name_prefix=name-/name_regex=t/tags=team-payments", "name-two"),
+	)
+})
+
+var _ = Describe("DataSource application space, application and agent lists", func() {
+	var (
+		mockServer *httptest.Server
+		meta       any
+		pageTokens []string
+	)
+
+	BeforeEach(func() {
+		pageTokens = nil
+		named := func(names ...string) []map[string]any {
+			out := make([]map[string]any, len(names))
+			for i, name := range names {
+				out[i] = map[string]any{"id": sampleID, "name": name}
+			}
+			return out
+		}
+		pages := map[string]any{
+			"":   map[string]any{"data": named("name-one", "other"), "next_page_token": "p2"},
+			"p2": map[string]any{"data": named("other", "name-two"), "next_page_token": "p3"},
+			"p3": map[string]any{"data": named("name-one")},
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get("page_token")
+			pageTokens = append(pageTokens, token)
+			_ = json.NewEncoder(w).Encode(pages[token])
+		}))
+
+		provider := indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		meta = provider.Meta()
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	read := func(
+		dataSourceType, scopeAttr, scopeID string,
+		raw map[string]any,
+	) (*schema.ResourceData, diag.Diagnostics) {
+		dataSource := indykite.Provider().DataSourcesMap[dataSourceType]
+		raw[scopeAttr] = scopeID
+		data := schema.TestResourceDataRaw(GinkgoT(), dataSource.Schema, raw)
+		return data, dataSource.ReadContext(context.Background(), data, meta)
+	}
+
+	DescribeTable("stops after max_results entries",
+		func(dataSourceType, scopeAttr, scopeID, listAttr string) {
+			data, d := read(dataSourceType, scopeAttr, scopeID,
+				map[string]any{"filter": []any{"name-one", "name-two"}, "max_results": 2})
+			Expect(d).To(BeEmpty())
+			Expect(pageTokens).To(Equal([]string{"", "p2"}))
+			Expect(data.Get(listAttr + ".#")).To(Equal(2))
+			Expect(data.Get(listAttr + ".1.name")).To(Equal("name-two"))
+		},
+		Entry("application spaces", "indykite_application_spaces", "customer_id", customerID, "app_spaces"),
+		Entry("applications", "indykite_applications", "app_space_id", appSpaceID, "applications"),
+		Entry("application agents", "indykite_application_agents", "app_space_id", appSpaceID, "app_agents"),
+	)
+
+	DescribeTable("filters entries",
+		func(dataSourceType, scopeAttr, scopeID, listAttr, idSegment string) {
+			filters := []struct {
+				raw           map[string]any
+				expectedID    string
+				expectedNames []string
+			}{
+				{map[string]any{}, "", []string{"name-one", "other", "other", "name-two", "name-one"}},
+				{map[string]any{"filter": []any{"name-two"}}, "/name-two", []string{"name-two"}},
+				{map[string]any{"name_prefix": "oth"}, "/name_prefix=oth", []string{"other", "other"}},
+				{map[string]any{"name_regex": "-two$"}, "/name_regex=-two$", []string{"name-two"}},
+				{
+					map[string]any{"filter": []any{"name-one", "name-two"}, "name_prefix": "name-", "name_regex": "t"},
+					"/name-one,name-two/name_prefix=name-/name_regex=t",
+					[]string{"name-two"},
+				},
+			}
+			for _, f := range filters {
+				data, d := read(dataSourceType, scopeAttr, scopeID, f.raw)
+				Expect(d).To(BeEmpty())
+				Expect(data.Id()).To(Equal(scopeID + idSegment + f.expectedID))
+				names := []string{}
+				for _, entry := range data.Get(listAttr).([]any) {
+					names = append(names, entry.(map[string]any)["name"].(string))
+				}
+				Expect(names).To(Equal(f.expectedNames), "filter %v", f.raw)
+			}
+		},
+		Entry("application spaces",
+			"indykite_application_spaces", "customer_id", customerID, "app_spaces", "/appSpaces"),
+		Entry("applications",
+			"indykite_applications", "app_space_id", appSpaceID, "applications", "/apps"),
+		Entry("application agents",
+			"indykite_application_agents", "app_space_id", appSpaceID, "app_agents", "/app_agents"),
+	)
+})
@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceMCPServer() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceMCPServer(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceMCPServerList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[appAgentIDKey] = computedStringSchema(appAgentIDDescription)
@@ -42,7 +47,7 @@ func dataSourceMCPServerList() *schema.Resource {
 		listAttrName:    "mcp_servers",
 		fullFetch:       true,
 		withFilter:      true,
-		description:     "List MCP Server configurations in the given Application Space, filtered by exact name match.",
+		description:     "List MCP Server configurations in the given Application Space, optionally filtered by name.",
 		entrySchema:     entrySchema,
 		flatten: func(server *MCPServerResponse) map[string]any {
 			return map[string]any{
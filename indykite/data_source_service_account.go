@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceServiceAccount() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceServiceAccount(), scopeKey: customerIDKey}
+	return single.dataSource()
+}
+
 func dataSourceServiceAccountList() *schema.Resource {
 	list := &restListDataSource[ServiceAccountResponse]{
 		path:            "/service-accounts",
@@ -26,7 +31,7 @@ func dataSourceServiceAccountList() *schema.Resource {
 		listAttrName:    "service_accounts",
 		fullFetch:       false,
 		withFilter:      true,
-		description:     "List Service Accounts in the given Customer, filtered by exact name match.",
+		description:     "List Service Accounts in the given Customer, optionally filtered by name.",
 		entrySchema:     listEntryCommonSchema(false),
 		flatten: func(account *ServiceAccountResponse) map[string]any {
 			return map[string]any{
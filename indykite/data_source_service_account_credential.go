@@ -38,6 +38,7 @@ func dataSourceServiceAccountCredentialList() *schema.Resource {
 		listAttrName:    "service_account_credentials",
 		fullFetch:       false,
 		withFilter:      false,
+		withExpiry:      true,
 		description:     "List Service Account Credentials in the given Customer.",
 		entrySchema:     entrySchema,
 		flatten: func(credential *ServiceAccountCredentialResponse) map[string]any {
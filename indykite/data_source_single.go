@@ -0,0 +1,112 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceIDKey = "id"
+
+// restSingleDataSource exposes one config resource as a data source.
+// The resource schema is reused with all attributes turned computed and the resource ReadContext
+// does the lookup, so the entry can be found either by "id", or by name within the parent scope.
+// The name lookup uses the same "name?location=gid:..." ID as import, translated by buildReadPath.
+type restSingleDataSource struct {
+	resource *schema.Resource
+	// scopeKey is the resource attribute holding the parent scope GID: locationKey or customerIDKey.
+	scopeKey string
+}
+
+func (s *restSingleDataSource) dataSource() *schema.Resource {
+	oneOfID := []string{dataSourceIDKey, nameKey}
+
+	dataSchema := computedSchemaMap(s.resource.Schema)
+	// Protects only resources managed by Terraform, meaningless for data sources
+	delete(dataSchema, deletionProtectionKey)
+	dataSchema[dataSourceIDKey] = setExactlyOneOf(
+		baseIDSchema("Identifier of the resource. Either this or `name` must be specified."),
+		dataSourceIDKey, oneOfID)
+	dataSchema[nameKey] = setRequiredWith(
+		setExactlyOneOf(nameSchema(), nameKey, oneOfID), s.scopeKey)
+	scope := *s.resource.Schema[s.scopeKey]
+	scope.ForceNew = false
+	scope.ConflictsWith = []string{dataSourceIDKey}
+	dataSchema[s.scopeKey] = convertToOptional(&scope)
+	for _, key := range []string{dataSourceIDKey, nameKey, s.scopeKey} {
+		dataSchema[key].Computed = true
+	}
+
+	return &schema.Resource{
+		Description: s.resource.Description,
+		ReadContext: s.readContext,
+		Schema:      dataSchema,
+		Timeouts:    defaultDataTimeouts(),
+	}
+}
+
+func (s *restSingleDataSource) readContext(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	if id, ok := data.GetOk(dataSourceIDKey); ok {
+		data.SetId(id.(string))
+	} else {
+		data.SetId(data.Get(nameKey).(string) + "?location=" + data.Get(s.scopeKey).(string))
+	}
+	lookupID := data.Id()
+
+	d := s.resource.ReadContext(ctx, data, meta)
+	// Resource read only warns and removes the ID when the entry is gone, data source must fail instead
+	if !d.HasError() && data.Id() == "" {
+		d = append(d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Data source lookup did not find any entry",
+			Detail:   "No entry found for " + lookupID,
+		})
+	}
+	return d
+}
+
+// computedSchemaMap returns a copy of the resource schema, where every attribute,
+// including nested ones, is computed only and all config related settings are dropped.
+func computedSchemaMap(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(in))
+	for key, attr := range in {
+		computed := *attr
+		setComputed(&computed)
+		computed.Default = nil
+		computed.DefaultFunc = nil
+		computed.StateFunc = nil
+		computed.MaxItems = 0
+		computed.ConflictsWith = nil
+		computed.ExactlyOneOf = nil
+		computed.AtLeastOneOf = nil
+		computed.RequiredWith = nil
+		computed.DiffSuppressOnRefresh = false
+
+		switch elem := attr.Elem.(type) {
+		case *schema.Resource:
+			computed.Elem = &schema.Resource{Schema: computedSchemaMap(elem.Schema)}
+		case *schema.Schema:
+			elemCopy := *elem
+			elemCopy.ValidateFunc = nil
+			elemCopy.ValidateDiagFunc = nil
+			computed.Elem = &elemCopy
+		}
+		out[key] = &computed
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DataSource config single entry reading", func() {
+	var (
+		mockServer *httptest.Server
+		meta       any
+		requested  []*url.URL
+		respBody   any
+	)
+
+	BeforeEach(func() {
+		requested = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL)
+			if respBody == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":5,"message":"not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(respBody)
+		}))
+
+		provider := indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		meta = provider.Meta()
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+		respBody = nil
+	})
+
+	read := func(dataSourceType string, raw map[string]any) (*schema.ResourceData, diag.Diagnostics) {
+		dataSource := indykite.Provider().DataSourcesMap[dataSourceType]
+		Expect(dataSource).NotTo(BeNil())
+		data := schema.TestResourceDataRaw(GinkgoT(), dataSource.Schema, raw)
+		return data, dataSource.ReadContext(context.Background(), data, meta)
+	}
+
+	It("reads authorization policy by id", func() {
+		respBody = indykite.AuthorizationPolicyResponse{
+			ID: sampleID, CustomerID: customerID, AppSpaceID: appSpaceID, Name: "my-policy",
+			Policy: `{"meta":{"policyVersion":"1.0-indykite"}}`, Status: "ACTIVE", Tags: []string{"tag1"},
+		}
+		data, d := read("indykite_authorization_policy", map[string]any{"id": sampleID})
+		Expect(d).To(BeEmpty())
+		Expect(requested).To(HaveLen(1))
+		Expect(requested[0].Path).To(Equal("/configs/v1/authorization-policies/" + sampleID))
+		Expect(data.Id()).To(Equal(sampleID))
+		Expect(data.Get("name")).To(Equal("my-policy"))
+		Expect(data.Get("location")).To(Equal(appSpaceID))
+		Expect(data.Get("json")).To(Equal(`{"meta":{"policyVersion":"1.0-indykite"}}`))
+		Expect(data.Get("status")).To(Equal("active"))
+		Expect(data.Get("tags")).To(Equal([]any{"tag1"}))
+	})
+
+	It("reads token introspect by name within application space", func() {
+		respBody = indykite.TokenIntrospectResponse{
+			ID: sampleID, CustomerID: customerID, AppSpaceID: appSpaceID,
+			Name: "my-introspect", IKGNodeType: "Person",
+		}
+		data, d := read("indykite_token_introspect", map[string]any{
+			"name":     "my-introspect",
+			"location": appSpaceID,
+		})
+		Expect(d).To(BeEmpty())
+		Expect(requested).To(HaveLen(1))
+		Expect(requested[0].Path).To(Equal("/configs/v1/token-introspects/my-introspect"))
+		Expect(requested[0].Query().Get("project_id")).To(Equal(appSpaceID))
+		Expect(data.Id()).To(Equal(sampleID))
+		Expect(data.Get("ikg_node_type")).To(Equal("Person"))
+	})
+
+	It("reads service account by name within organization", func() {
+		respBody = indykite.ServiceAccountResponse{
+			ID: serviceAccountID, OrganizationID: customerID, Name: "my-sa", Role: "all_viewer",
+		}
+		data, d := read("indykite_service_account", map[string]any{
+			"name":        "my-sa",
+			"customer_id": customerID,
+		})
+		Expect(d).To(BeEmpty())
+		Expect(requested[0].Path).To(Equal("/configs/v1/service-accounts/my-sa"))
+		Expect(requested[0].Query().Get("organization_id")).To(Equal(customerID))
+		Expect(data.Id()).To(Equal(serviceAccountID))
+		Expect(data.Get("role")).To(Equal("all_viewer"))
+	})
+
+	It("reports missing entry as error", func() {
+		_, d := read("indykite_mcp_server", map[string]any{"id": sampleID})
+		Expect(d).To(ContainElement(HaveField("Severity", diag.Error)))
+	})
+
+	It("exposes every resource attribute as computed", func() {
+		for _, name := range []string{
+			"indykite_authorization_policy", "indykite_knowledge_query", "indykite_token_introspect",
+			"indykite_external_data_resolver", "indykite_entity_matching_pipeline",
+			"indykite_trust_score_profile", "indykite_mcp_server",
+			"indykite_service_account",
+		} {
+			dataSource := indykite.Provider().DataSourcesMap[name]
+			Expect(dataSource).NotTo(BeNil(), name)
+			for key, attr := range indykite.Provider().ResourcesMap[name].Schema {
+				if key == "deletion_protection" {
+					Expect(dataSource.Schema).NotTo(HaveKey(key), name)
+					continue
+				}
+				Expect(dataSource.Schema).To(HaveKey(key), name)
+				Expect(dataSource.Schema[key].Computed).To(BeTrue(), name+"."+key)
+				Expect(dataSource.Schema[key].Required).To(BeFalse(), name+"."+key)
+				Expect(dataSource.Schema[key].Type).To(Equal(attr.Type), name+"."+key)
+			}
+			Expect(dataSource.Schema["id"].ExactlyOneOf).To(ConsistOf("id", "name"), name)
+		}
+	})
+})
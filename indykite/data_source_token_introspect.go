@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceTokenIntrospect() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceTokenIntrospect(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceTokenIntrospectList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[tokenIntrospectIKGNodeTypeKey] = computedStringSchema("IKG node type the introspected token maps to")
@@ -35,7 +40,7 @@ func dataSourceTokenIntrospectList() *schema.Resource {
 		fullFetch:       true,
 		withFilter:      true,
 		description: "List Token Introspect configurations in the given Application Space, " +
-			"filtered by exact name match.",
+			"optionally filtered by name.",
 		entrySchema: entrySchema,
 		flatten: func(ti *TokenIntrospectResponse) map[string]any {
 			return map[string]any{
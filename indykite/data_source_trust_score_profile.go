@@ -18,6 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func dataSourceTrustScoreProfile() *schema.Resource {
+	single := &restSingleDataSource{resource: resourceTrustScoreProfile(), scopeKey: locationKey}
+	return single.dataSource()
+}
+
 func dataSourceTrustScoreProfileList() *schema.Resource {
 	entrySchema := listEntryCommonSchema(true)
 	entrySchema[trustScoreProfileNodeClassification] = computedStringSchema(
@@ -31,7 +36,7 @@ func dataSourceTrustScoreProfileList() *schema.Resource {
 		listAttrName:    "trust_score_profiles",
 		fullFetch:       true,
 		withFilter:      true,
-		description:     "List Trust Score Profiles in the given Application Space, filtered by exact name match.",
+		description:     "List Trust Score Profiles in the given Application Space, optionally filtered by name.",
 		entrySchema:     entrySchema,
 		flatten: func(profile *TrustScoreProfileResponse) map[string]any {
 			return map[string]any{
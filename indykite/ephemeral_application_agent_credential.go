@@ -0,0 +1,242 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+const (
+	expireInKey        = "expire_in"
+	generateKeyPairKey = "generate_key_pair"
+
+	defaultEphemeralCredentialExpireIn = time.Hour
+	// ephemeralCredentialIDKey holds ID of the opened credential in private data, so it can be deleted on close.
+	ephemeralCredentialIDKey = "credential_id"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &appAgentCredentialEphemeral{}
+	_ ephemeral.EphemeralResourceWithClose     = &appAgentCredentialEphemeral{}
+)
+
+type (
+	// appAgentCredentialEphemeral creates short-lived application agent credential on open and deletes it on close.
+	// Unlike the resource, the private key is never stored in the plan or state.
+	appAgentCredentialEphemeral struct {
+		clientCtx *ClientContext
+	}
+
+	appAgentCredentialEphemeralModel struct {
+		ID                 types.String `tfsdk:"id"`
+		ApplicationAgentID types.String `tfsdk:"app_agent_id"`
+		DisplayName        types.String `tfsdk:"display_name"`
+		ExpireIn           types.String `tfsdk:"expire_in"`
+		GenerateKeyPair    types.Bool   `tfsdk:"generate_key_pair"`
+		CustomerID         types.String `tfsdk:"customer_id"`
+		AppSpaceID         types.String `tfsdk:"app_space_id"`
+		ApplicationID      types.String `tfsdk:"application_id"`
+		Kid                types.String `tfsdk:"kid"`
+		AgentConfig        types.String `tfsdk:"agent_config"`
+		ExpireTime         types.String `tfsdk:"expire_time"`
+		CreateTime         types.String `tfsdk:"create_time"`
+	}
+)
+
+func newAppAgentCredentialEphemeral() ephemeral.EphemeralResource {
+	return &appAgentCredentialEphemeral{}
+}
+
+func (*appAgentCredentialEphemeral) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_application_agent_credential"
+}
+
+func (*appAgentCredentialEphemeral) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Short-lived application agent credentials, created when Terraform opens the ephemeral resource " +
+			"and deleted when it is closed. The credential is never stored in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier of the credential.",
+			},
+			appAgentIDKey: schema.StringAttribute{
+				Required:    true,
+				Description: appAgentIDDescription,
+				Validators:  []validator.String{gidValidator()},
+			},
+			displayNameKey: schema.StringAttribute{
+				Optional:    true,
+				Description: "Display name of the credential.",
+			},
+			expireInKey: schema.StringAttribute{
+				Optional: true,
+				Description: "Lifetime of the credential as Go duration, for example `30m`. " +
+					"Defaults to `1h`. The credential expires even when Terraform fails to delete it.",
+				Validators: []validator.String{durationValidator()},
+			},
+			generateKeyPairKey: schema.BoolAttribute{
+				Optional: true,
+				Description: "Generate EC P-256 key pair locally and upload only the public key, " +
+					"so the private key never crosses the API. It is then available only in `agent_config`.",
+			},
+			customerIDKey: schema.StringAttribute{
+				Computed:    true,
+				Description: customerIDDescription,
+			},
+			appSpaceIDKey: schema.StringAttribute{
+				Computed:    true,
+				Description: appSpaceIDDescription,
+			},
+			applicationIDKey: schema.StringAttribute{
+				Computed:    true,
+				Description: applicationIDDescription,
+			},
+			kidKey: schema.StringAttribute{
+				Computed:    true,
+				Description: "Key ID of the credential.",
+			},
+			agentConfigKey: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Application agent configuration with the private key, in JSON format.",
+			},
+			expireTimeKey: schema.StringAttribute{
+				Computed:    true,
+				Description: "Date-time when the credential expires.",
+			},
+			createTimeKey: schema.StringAttribute{
+				Computed:    true,
+				Description: createTimeSchema().Description,
+			},
+		},
+	}
+}
+
+func (e *appAgentCredentialEphemeral) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	// Provider is not configured yet during validation
+	if req.ProviderData == nil {
+		return
+	}
+	e.clientCtx = frameworkClientContext(&resp.Diagnostics, req.ProviderData)
+}
+
+func (e *appAgentCredentialEphemeral) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var model appAgentCredentialEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
+	defer cancel()
+
+	expireIn := defaultEphemeralCredentialExpireIn
+	if !model.ExpireIn.IsNull() {
+		// Already validated by durationValidator
+		expireIn, _ = time.ParseDuration(model.ExpireIn.ValueString())
+	}
+	createReq := CreateApplicationAgentCredentialRequest{
+		ApplicationAgentID: model.ApplicationAgentID.ValueString(),
+		DisplayName:        model.DisplayName.ValueString(),
+		ExpireTime:         time.Now().Add(expireIn).UTC().Format(time.RFC3339),
+	}
+	var privateKey jwk.Key
+	if model.GenerateKeyPair.ValueBool() {
+		var err error
+		if privateKey, createReq.PublicKeyJWK, err = generateAgentKeyPair(); err != nil {
+			resp.Diagnostics.AddError("Key pair generation failed", err.Error())
+			return
+		}
+	}
+
+	created, err := postAppAgentCredentialWithRetry(ctx, e.clientCtx.GetClient(), &createReq)
+	if IsNotFoundError(err) {
+		resp.Diagnostics.AddError("Application agent not found", fmt.Sprintf(
+			"application agent %q was not found after %d attempts; it may not have "+
+				"finished propagating on the backend",
+			createReq.ApplicationAgentID, credCreateMaxRetries+1))
+		return
+	}
+	if frameworkHasFailed(&resp.Diagnostics, err) {
+		return
+	}
+	// Private data must be JSON
+	rawID, _ := json.Marshal(created.ID)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralCredentialIDKey, rawID)...)
+
+	model.ID = types.StringValue(created.ID)
+	model.CustomerID = stringOrNull(created.CustomerID)
+	model.AppSpaceID = stringOrNull(created.AppSpaceID)
+	model.ApplicationID = stringOrNull(created.ApplicationID)
+	model.Kid = stringOrNull(created.Kid)
+	model.AgentConfig = stringOrNull(string(created.AgentConfig))
+	if privateKey != nil {
+		agentConfig, err := agentConfigWithPrivateKey(created.AgentConfig, privateKey, created.Kid)
+		if err != nil {
+			resp.Diagnostics.AddError("Agent configuration failed", err.Error())
+			return
+		}
+		model.AgentConfig = types.StringValue(agentConfig)
+	}
+	model.ExpireTime = timeOrNull(created.ExpireTime)
+	model.CreateTime = timeOrNull(created.CreateTime)
+	if model.ExpireTime.IsNull() {
+		model.ExpireTime = types.StringValue(createReq.ExpireTime)
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (e *appAgentCredentialEphemeral) Close(
+	ctx context.Context,
+	req ephemeral.CloseRequest,
+	resp *ephemeral.CloseResponse,
+) {
+	rawID, diags := req.Private.GetKey(ctx, ephemeralCredentialIDKey)
+	resp.Diagnostics.Append(diags...)
+	var id string
+	if resp.Diagnostics.HasError() || json.Unmarshal(rawID, &id) != nil || id == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
+	defer cancel()
+
+	err := e.clientCtx.GetClient().Delete(ctx, "/application-agent-credentials/"+id)
+	frameworkHasFailed(&resp.Diagnostics, err)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ephemeral application agent credential", func() {
+	const typeName = "indykite_application_agent_credential"
+	var (
+		mockServer *httptest.Server
+		server     tfprotov6.ProviderServer
+		schemas    *tfprotov6.GetProviderSchemaResponse
+		created    indykite.CreateApplicationAgentCredentialRequest
+		deleted    []string
+		ctx        = context.Background()
+	)
+
+	BeforeEach(func() {
+		created = indykite.CreateApplicationAgentCredentialRequest{}
+		DeferCleanup(indykite.SetCredCreateWaits(time.Millisecond, time.Millisecond, time.Millisecond))
+		deleted = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				Expect(json.NewDecoder(r.Body).Decode(&created)).To(Succeed())
+				expireTime, _ := time.Parse(time.RFC3339, created.ExpireTime)
+				_ = json.NewEncoder(w).Encode(indykite.ApplicationAgentCredentialResponse{
+					ID: appAgentCredID, Kid: "kid-1", CustomerID: customerID, AppSpaceID: appSpaceID,
+					ApplicationID: applicationID, ApplicationAgentID: created.ApplicationAgentID,
+					ExpireTime: expireTime, CreateTime: time.Now(),
+					AgentConfig: json.RawMessage(`{"privateKeyJWK":{}}`),
+				})
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+				_, _ = w.Write([]byte(`{}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		server, schemas = configuredMuxServer(mockServer)
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	open := func(config map[string]any) *tfprotov6.OpenEphemeralResourceResponse {
+		typ := schemas.EphemeralResourceSchemas[typeName].ValueType()
+		dv, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, config))
+		Expect(err).To(Succeed())
+		resp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+			TypeName: typeName,
+			Config:   &dv,
+		})
+		Expect(err).To(Succeed())
+		return resp
+	}
+
+	It("creates short-lived credential on open and deletes it on close", func() {
+		before := time.Now()
+		resp := open(map[string]any{"app_agent_id": appAgentID, "expire_in": "15m"})
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		Expect(created.ApplicationAgentID).To(Equal(appAgentID))
+		expireTime, err := time.Parse(time.RFC3339, created.ExpireTime)
+		Expect(err).To(Succeed())
+		Expect(expireTime).To(BeTemporally("~", before.Add(15*time.Minute), time.Minute))
+
+		result, err := resp.Result.Unmarshal(schemas.EphemeralResourceSchemas[typeName].ValueType())
+		Expect(err).To(Succeed())
+		values := indykite.StateValue(result).(map[string]any)
+		Expect(values).To(HaveKeyWithValue("id", appAgentCredID))
+		Expect(values).To(HaveKeyWithValue("kid", "kid-1"))
+		Expect(values).To(HaveKeyWithValue("agent_config", `{"privateKeyJWK":{}}`))
+		Expect(values).To(HaveKeyWithValue("expire_time", Not(BeEmpty())))
+
+		closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+			TypeName: typeName,
+			Private:  resp.Private,
+		})
+		Expect(err).To(Succeed())
+		Expect(closeResp.Diagnostics).To(BeEmpty())
+		Expect(deleted).To(ConsistOf("/configs/v1/application-agent-credentials/" + appAgentCredID))
+	})
+
+	It("defaults expiration to one hour", func() {
+		before := time.Now()
+		Expect(open(map[string]any{"app_agent_id": appAgentID}).Diagnostics).To(BeEmpty())
+		expireTime, err := time.Parse(time.RFC3339, created.ExpireTime)
+		Expect(err).To(Succeed())
+		Expect(expireTime).To(BeTemporally("~", before.Add(time.Hour), time.Minute))
+	})
+
+	It("uploads only public key of locally generated key pair", func() {
+		resp := open(map[string]any{"app_agent_id": appAgentID, "generate_key_pair": true})
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		publicKey, err := jwk.ParseKey([]byte(created.PublicKeyJWK))
+		Expect(err).To(Succeed())
+		Expect(publicKey.KeyType()).To(Equal(jwa.EC))
+		Expect(created.PublicKeyJWK).NotTo(ContainSubstring(`"d"`))
+
+		result, err := resp.Result.Unmarshal(schemas.EphemeralResourceSchemas[typeName].ValueType())
+		Expect(err).To(Succeed())
+		var agentConfig struct {
+			PrivateKeyJWK json.RawMessage `json:"privateKeyJWK"`
+		}
+		values := indykite.StateValue(result).(map[string]any)
+		Expect(json.Unmarshal([]byte(values["agent_config"].(string)), &agentConfig)).To(Succeed())
+		privateKey, err := jwk.ParseKey(agentConfig.PrivateKeyJWK)
+		Expect(err).To(Succeed())
+		Expect(privateKey.KeyID()).To(Equal("kid-1"))
+		derivedPublicKey, err := privateKey.PublicKey()
+		Expect(err).To(Succeed())
+		Expect(jwk.Equal(derivedPublicKey, publicKey)).To(BeTrue())
+	})
+
+	It("rejects invalid expire_in", func() {
+		typ := schemas.EphemeralResourceSchemas[typeName].ValueType()
+		dv, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, map[string]any{
+			"app_agent_id": appAgentID, "expire_in": "tomorrow",
+		}))
+		Expect(err).To(Succeed())
+		resp, err := server.ValidateEphemeralResourceConfig(ctx, &tfprotov6.ValidateEphemeralResourceConfigRequest{
+			TypeName: typeName,
+			Config:   &dv,
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(ContainElement(HaveField("Severity", tfprotov6.DiagnosticSeverityError)))
+	})
+})
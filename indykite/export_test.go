@@ -14,7 +14,12 @@
 
 package indykite
 
-import "time"
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
 
 // SetCredCreateWaits overrides the application agent credential create initial
 // wait and retry backoff bounds for tests and returns a function that restores
@@ -41,3 +46,28 @@ func SetAgentCreateWait(d time.Duration) func() {
 		agentCreateInitialWait = orig
 	}
 }
+
+// SetJWTLifetime overrides the self-signed JWT lifetime and refresh margin for
+// tests and returns a function that restores the original values.
+func SetJWTLifetime(lifetime, refreshMargin time.Duration) func() {
+	origLifetime, origMargin := jwtLifetime, jwtRefreshMargin
+	jwtLifetime, jwtRefreshMargin = lifetime, refreshMargin
+	return func() {
+		jwtLifetime, jwtRefreshMargin = origLifetime, origMargin
+	}
+}
+
+// CurrentRateLimit returns the adapted requests per second of the client throttling.
+func CurrentRateLimit(c *RestClient) float64 {
+	return float64(c.throttle.limiter.Limit())
+}
+
+// ParseRestError exposes decoding of Config API error responses to tests.
+func ParseRestError(statusCode int, header http.Header, body []byte) *RestError {
+	return parseRestError(statusCode, header, body)
+}
+
+// StateValue exposes conversion of protocol values into plain Go values to tests.
+func StateValue(value tftypes.Value) any {
+	return stateValue(value)
+}
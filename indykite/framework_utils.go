@@ -0,0 +1,368 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const timeoutsKey = "timeouts"
+
+// Write-only variant of a secret attribute has the suffix, together with the version attribute,
+// whose change makes Terraform send the write-only value again.
+const (
+	writeOnlySuffix        = "_wo"
+	writeOnlyVersionSuffix = "_wo_version"
+)
+
+const (
+	timeoutCreateKey  = "create"
+	timeoutReadKey    = "read"
+	timeoutUpdateKey  = "update"
+	timeoutDeleteKey  = "delete"
+	timeoutDefaultKey = "default"
+
+	defaultOperationTimeout = 4 * time.Minute
+)
+
+// frameworkClientContext converts provider data of framework resources into ClientContext.
+func frameworkClientContext(d *fwdiag.Diagnostics, providerData any) *ClientContext {
+	clientCtx, ok := providerData.(*ClientContext)
+	if !ok || clientCtx == nil {
+		d.AddError("Unable retrieve IndyKite client from meta",
+			"This is always an issue in the provider implementation and should be reported to the provider developers.")
+	}
+	return clientCtx
+}
+
+// frameworkHasFailed is HasFailed for framework resources, so both share the error reporting.
+func frameworkHasFailed(d *fwdiag.Diagnostics, err error) bool {
+	var sdkDiags diag.Diagnostics
+	if !HasFailed(&sdkDiags, err) {
+		return false
+	}
+	d.Append(frameworkDiagnostics(sdkDiags)...)
+	return true
+}
+
+// frameworkDiagnostics converts SDK diagnostics, including attribute paths, into framework diagnostics.
+func frameworkDiagnostics(sdkDiags diag.Diagnostics) fwdiag.Diagnostics {
+	var d fwdiag.Diagnostics
+	for _, sdkDiag := range sdkDiags {
+		attrPath := frameworkPath(sdkDiag.AttributePath)
+		switch {
+		case sdkDiag.Severity == diag.Warning && len(sdkDiag.AttributePath) > 0:
+			d.AddAttributeWarning(attrPath, sdkDiag.Summary, sdkDiag.Detail)
+		case sdkDiag.Severity == diag.Warning:
+			d.AddWarning(sdkDiag.Summary, sdkDiag.Detail)
+		case len(sdkDiag.AttributePath) > 0:
+			d.AddAttributeError(attrPath, sdkDiag.Summary, sdkDiag.Detail)
+		default:
+			d.AddError(sdkDiag.Summary, sdkDiag.Detail)
+		}
+	}
+	return d
+}
+
+func frameworkPath(ctyPath cty.Path) path.Path {
+	var p path.Path
+	for _, step := range ctyPath {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			p = p.AtName(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.String {
+				p = p.AtMapKey(s.Key.AsString())
+			} else {
+				i, _ := s.Key.AsBigFloat().Int64()
+				p = p.AtListIndex(int(i))
+			}
+		}
+	}
+	return p
+}
+
+// sdkStringValidator runs SDK validation function, so framework attributes report the same errors.
+type sdkStringValidator struct {
+	validate    func(any, cty.Path) diag.Diagnostics
+	description string
+}
+
+var _ validator.String = sdkStringValidator{}
+
+func gidValidator() validator.String {
+	return sdkStringValidator{validate: ValidateGID, description: "value must be valid GID"}
+}
+
+func nameValidator() validator.String {
+	return sdkStringValidator{validate: ValidateName, description: "value must be valid name"}
+}
+
+func durationValidator() validator.String {
+	return sdkStringValidator{validate: ValidateDuration, description: "value must be positive Go duration"}
+}
+
+func (v sdkStringValidator) Description(context.Context) string {
+	return v.description
+}
+
+func (v sdkStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sdkStringValidator) ValidateString(
+	_ context.Context,
+	req validator.StringRequest,
+	resp *validator.StringResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, d := range frameworkDiagnostics(v.validate(req.ConfigValue.ValueString(), nil)) {
+		// Validation functions do not know the path, all diagnostics belong to validated attribute
+		if d.Severity() == fwdiag.SeverityError {
+			resp.Diagnostics.AddAttributeError(req.Path, d.Summary(), d.Detail())
+		} else {
+			resp.Diagnostics.AddAttributeWarning(req.Path, d.Summary(), d.Detail())
+		}
+	}
+}
+
+// secretAttributes returns attributes of the secret stored as sensitive value in the state,
+// its write-only variant never stored in the state, and version of the write-only value.
+// Exactly one of the secret and its write-only variant must be set.
+func secretAttributes(key string, validators ...validator.String) map[string]schema.Attribute {
+	sibling := func(name string) path.Expression { return path.MatchRelative().AtParent().AtName(name) }
+	return map[string]schema.Attribute{
+		key: schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Stored in the state, use `" + key + writeOnlySuffix + "` to keep it out of the state.",
+			Validators: append(validators,
+				stringvalidator.ExactlyOneOf(sibling(key+writeOnlySuffix)),
+				stringvalidator.ConflictsWith(sibling(key+writeOnlyVersionSuffix))),
+		},
+		key + writeOnlySuffix: schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+			Description: "Write-only variant of `" + key + "`, which is never stored in the plan or state. " +
+				"Change `" + key + writeOnlyVersionSuffix + "` to send a new value. Requires Terraform 1.11 or later.",
+			Validators: validators,
+		},
+		key + writeOnlyVersionSuffix: schema.Int64Attribute{
+			Optional: true,
+			Description: "Version of `" + key + writeOnlySuffix + "`, " +
+				"any change triggers update sending the current write-only value.",
+			Validators: []validator.Int64{int64validator.AlsoRequires(sibling(key + writeOnlySuffix))},
+		},
+	}
+}
+
+// secretValue returns the write-only value when set, otherwise the value stored in the state.
+func secretValue(value, writeOnly types.String) string {
+	if !writeOnly.IsNull() && !writeOnly.IsUnknown() {
+		return writeOnly.ValueString()
+	}
+	return value.ValueString()
+}
+
+// resourceTimeoutsBlock returns the same timeouts block as SDK resources with defaultTimeouts have,
+// so existing configuration and state stay valid.
+func resourceTimeoutsBlock() schema.Block {
+	attributes := make(map[string]schema.Attribute)
+	timeoutKeys := []string{timeoutCreateKey, timeoutReadKey, timeoutUpdateKey, timeoutDeleteKey, timeoutDefaultKey}
+	for _, key := range timeoutKeys {
+		attributes[key] = schema.StringAttribute{Optional: true, Validators: []validator.String{durationValidator()}}
+	}
+	return schema.SingleNestedBlock{Attributes: attributes}
+}
+
+// dataSourceTimeoutsBlock returns the same timeouts block as SDK data sources with defaultDataTimeouts have.
+func dataSourceTimeoutsBlock() dsschema.Block {
+	attributes := make(map[string]dsschema.Attribute)
+	for _, key := range []string{timeoutReadKey, timeoutDefaultKey} {
+		attributes[key] = dsschema.StringAttribute{Optional: true, Validators: []validator.String{durationValidator()}}
+	}
+	return dsschema.SingleNestedBlock{Attributes: attributes}
+}
+
+// operationTimeout returns timeout of the operation from timeouts block,
+// falling back to its default value and then to the default of all resources.
+func operationTimeout(timeouts types.Object, operation string) time.Duration {
+	for _, key := range []string{operation, timeoutDefaultKey} {
+		if value, ok := timeouts.Attributes()[key].(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			if d, err := time.ParseDuration(value.ValueString()); err == nil {
+				return d
+			}
+		}
+	}
+	return defaultOperationTimeout
+}
+
+// stringOrNull converts API value into string, where empty or missing value is null.
+func stringOrNull(value any) types.String {
+	if s, ok := value.(string); ok && s != "" {
+		return types.StringValue(s)
+	}
+	return types.StringNull()
+}
+
+// timeOrNull converts API timestamp into string in the same format SDK resources use.
+func timeOrNull(value time.Time) types.String {
+	if value.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(value.Format(time.RFC3339Nano))
+}
+
+// displayNameOrNull converts display name of API response, which returns the name when display name is not set.
+// It is kept null in that case, unless it was set before.
+func displayNameOrNull(displayName, name string, prior types.String) types.String {
+	if displayName == name && prior.IsNull() {
+		return types.StringNull()
+	}
+	return stringOrNull(displayName)
+}
+
+// changedString returns pointer to the new value when it differs from the old one, as update requests expect.
+func changedString(newValue, oldValue types.String) *string {
+	if newValue.Equal(oldValue) {
+		return nil
+	}
+	v := newValue.ValueString()
+	return &v
+}
+
+// stringValues converts list of framework strings into plain strings, null and unknown values are skipped.
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !v.IsNull() && !v.IsUnknown() {
+			result = append(result, v.ValueString())
+		}
+	}
+	return result
+}
+
+// computedDataSourceAttributes converts resource schema into data source attributes,
+// where every attribute, including nested ones, is computed only. It is the framework variant of computedSchemaMap.
+// Nested blocks become computed nested attributes, timeouts block is dropped.
+func computedDataSourceAttributes(
+	attributes map[string]schema.Attribute,
+	blocks map[string]schema.Block,
+) map[string]dsschema.Attribute {
+	out := make(map[string]dsschema.Attribute, len(attributes)+len(blocks))
+	for key, attr := range attributes {
+		// Write-only secrets are never stored, their versions make no sense without them
+		if attr.IsWriteOnly() || isWriteOnlyVersion(attributes, key) {
+			continue
+		}
+		switch a := attr.(type) {
+		case schema.StringAttribute:
+			out[key] = dsschema.StringAttribute{
+				Computed: true, Sensitive: a.Sensitive, Description: a.Description,
+			}
+		case schema.BoolAttribute:
+			out[key] = dsschema.BoolAttribute{
+				Computed: true, Sensitive: a.Sensitive, Description: a.Description,
+			}
+		case schema.Int64Attribute:
+			out[key] = dsschema.Int64Attribute{
+				Computed: true, Sensitive: a.Sensitive, Description: a.Description,
+			}
+		case schema.ListAttribute:
+			out[key] = dsschema.ListAttribute{
+				Computed: true, Sensitive: a.Sensitive, Description: a.Description, ElementType: a.ElementType,
+			}
+		}
+	}
+	for key, block := range blocks {
+		if b, ok := block.(schema.ListNestedBlock); ok {
+			out[key] = dsschema.ListNestedAttribute{
+				Computed:    true,
+				Description: b.Description,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: computedDataSourceAttributes(b.NestedObject.Attributes, b.NestedObject.Blocks),
+				},
+			}
+		}
+	}
+	return out
+}
+
+// projectValue converts value of the resource schema into type of the data source built by
+// computedDataSourceAttributes, so attributes left out of the data source are dropped.
+func projectValue(value tftypes.Value, typ tftypes.Type) (tftypes.Value, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	switch t := typ.(type) {
+	case tftypes.Object:
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return tftypes.Value{}, err
+		}
+		out := make(map[string]tftypes.Value, len(t.AttributeTypes))
+		for key, attrType := range t.AttributeTypes {
+			attr, ok := attributes[key]
+			if !ok {
+				attr = tftypes.NewValue(attrType, nil)
+			}
+			projected, err := projectValue(attr, attrType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			out[key] = projected
+		}
+		return tftypes.NewValue(t, out), nil
+	case tftypes.List:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return tftypes.Value{}, err
+		}
+		out := make([]tftypes.Value, len(elements))
+		for i, element := range elements {
+			projected, err := projectValue(element, t.ElementType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			out[i] = projected
+		}
+		return tftypes.NewValue(t, out), nil
+	}
+	return value, nil
+}
+
+// isWriteOnlyVersion reports whether key is the version attribute of a write-only attribute.
+func isWriteOnlyVersion(attributes map[string]schema.Attribute, key string) bool {
+	base, ok := strings.CutSuffix(key, writeOnlyVersionSuffix)
+	writeOnly := attributes[base+writeOnlySuffix]
+	return ok && writeOnly != nil && writeOnly.IsWriteOnly()
+}
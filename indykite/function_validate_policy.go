@@ -0,0 +1,69 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &validatePolicyFunction{}
+
+// validatePolicyFunction checks policy JSON against the embedded KBAC policy schema,
+// so modules can assert policies in precondition blocks before anything is sent to the API.
+type validatePolicyFunction struct{}
+
+func newValidatePolicyFunction() function.Function {
+	return &validatePolicyFunction{}
+}
+
+func (*validatePolicyFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "validate_policy"
+}
+
+func (*validatePolicyFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Check authorization policy JSON against the KBAC policy schema",
+		Description: "Returns `true` when the policy is JSON matching the KBAC policy schema, `false` otherwise. " +
+			"The same schema validates `json` of `indykite_authorization_policy` at plan time, " +
+			"which reports JSON pointer of every offending value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "Authorization policy in JSON format.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (*validatePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var policyJSON string
+	resp.Error = req.Arguments.Get(ctx, &policyJSON)
+	if resp.Error != nil {
+		return
+	}
+	violations, err := validatePolicyDocument(policyJSON)
+	resp.Error = resp.Result.Set(ctx, err == nil && len(violations) == 0)
+}
@@ -17,6 +17,8 @@ package indykite
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -27,62 +29,145 @@ var (
 	gidBase64Regex = regexp.MustCompile("^gid:[A-Za-z0-9_-]{22,}$")
 )
 
-func basicStateImporter(_ context.Context, data *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
-	if err := parseImportID(data); err != nil {
+// importSpec declares how a resource is imported and looked up by name.
+type importSpec struct {
+	// path is the REST collection path, e.g. "/authorization-policies".
+	path string
+	// scopeQueryParam is the query parameter carrying the parent scope GID of name lookups:
+	// "project_id" or "organization_id". Empty when the resource can be imported only by GID.
+	scopeQueryParam string
+}
+
+// Import specs of all importable resources.
+var (
+	appSpaceImport               = &importSpec{path: "/projects", scopeQueryParam: "organization_id"}
+	applicationImport            = &importSpec{path: "/applications", scopeQueryParam: "project_id"}
+	appAgentImport               = &importSpec{path: "/application-agents", scopeQueryParam: "project_id"}
+	appAgentCredentialImport     = &importSpec{path: "/application-agent-credentials"}
+	authorizationPolicyImport    = &importSpec{path: "/authorization-policies", scopeQueryParam: "project_id"}
+	entityMatchingPipelineImport = &importSpec{path: "/entity-matching-pipelines", scopeQueryParam: "project_id"}
+	eventSinkImport              = &importSpec{path: "/event-sinks", scopeQueryParam: "project_id"}
+	externalDataResolverImport   = &importSpec{path: "/external-data-resolvers", scopeQueryParam: "project_id"}
+	knowledgeQueryImport         = &importSpec{path: "/knowledge-queries", scopeQueryParam: "project_id"}
+	mcpServerImport              = &importSpec{path: "/mcp-servers", scopeQueryParam: "project_id"}
+	serviceAccountImport         = &importSpec{path: "/service-accounts", scopeQueryParam: "organization_id"}
+	serviceAccountCredImport     = &importSpec{path: "/service-account-credentials"}
+	tokenIntrospectImport        = &importSpec{path: "/token-introspects", scopeQueryParam: "project_id"}
+	trustScoreProfileImport      = &importSpec{path: "/trust-score-profiles", scopeQueryParam: "project_id"}
+)
+
+func (s *importSpec) importer() *schema.ResourceImporter {
+	return &schema.ResourceImporter{StateContext: s.stateContext}
+}
+
+// stateContext accepts import IDs in formats:
+//   - "gid:xxx" is used as is.
+//   - "resource-name?location=gid:xxx" with GID of the parent Application Space or Organization.
+//   - "location-name/resource-name" with name of the parent Application Space or Organization,
+//     which is resolved through the API.
+//
+// Name based IDs are looked up right away and replaced by the GID,
+// so the state never holds the name whatever the resource read does.
+func (s *importSpec) stateContext(
+	ctx context.Context,
+	data *schema.ResourceData,
+	meta any,
+) ([]*schema.ResourceData, error) {
+	clientCtx, _ := meta.(*ClientContext)
+	id, err := s.resolveImportID(ctx, clientCtx, data.Id())
+	if err != nil {
 		return nil, err
 	}
-
+	data.SetId(id)
 	return []*schema.ResourceData{data}, nil
 }
 
-func parseImportID(d *schema.ResourceData) error {
-	importID := d.Id()
-
-	// Check if it's a GID (direct ID import)
+// resolveImportID returns GID of the resource given by any of the import ID formats accepted by stateContext.
+func (s *importSpec) resolveImportID(ctx context.Context, clientCtx *ClientContext, importID string) (string, error) {
 	if gidBase64Regex.MatchString(importID) {
-		return nil
+		return importID, nil
+	}
+	if s.scopeQueryParam == "" {
+		return "", errors.New("Unimplemented id format: " + importID + ". Expected 'gid:xxx'")
+	}
+	if clientCtx == nil {
+		return "", errors.New("Unable retrieve IndyKite client from meta")
+	}
+	client := clientCtx.GetClient()
+
+	readID := importID
+	switch {
+	case strings.Contains(importID, "?location="):
+		_, location, _ := strings.Cut(importID, "?location=")
+		if !gidBase64Regex.MatchString(location) {
+			return "", errors.New("Invalid location in import id: " + importID + ". Expected 'gid:xxx'")
+		}
+	case strings.Count(importID, "/") == 1:
+		locationName, name, _ := strings.Cut(importID, "/")
+		location, err := s.resolveLocation(ctx, client, locationName)
+		if err != nil {
+			return "", err
+		}
+		readID = name + "?location=" + location
+	default:
+		return "", errors.New("Unimplemented id format: " + importID +
+			". Expected either 'gid:xxx', 'resource-name?location=gid:xxx' or 'location-name/resource-name'")
+	}
+
+	var resp BaseResponse
+	if err := client.Get(ctx, s.readPath(readID), &resp); err != nil {
+		return "", fmt.Errorf("cannot find %s to import: %w", importID, err)
+	}
+	if resp.ID == "" {
+		return "", errors.New("cannot import " + importID + ": response does not contain any ID")
 	}
+	return resp.ID, nil
+}
 
-	// Check if it's a name with location parameter (e.g., "my-resource?location=gid:xxx")
-	// The ID will be kept as-is and used in the read function
-	if strings.Contains(importID, "?location=") {
-		return nil
+// resolveLocation returns GID of the parent scope given by name, or by GID.
+// Organization must be the one of the service account used by the provider.
+func (s *importSpec) resolveLocation(ctx context.Context, client *RestClient, locationName string) (string, error) {
+	if gidBase64Regex.MatchString(locationName) {
+		return locationName, nil
 	}
 
-	return errors.New("Unimplemented id format: " + importID +
-		". Expected either 'gid:xxx' or 'resource-name?location=gid:xxx'")
+	var org CustomerResponse
+	if err := client.Get(ctx, "/organizations/current", &org); err != nil {
+		return "", fmt.Errorf("cannot read current organization: %w", err)
+	}
+	if s.scopeQueryParam == "organization_id" {
+		if locationName != org.Name {
+			return "", fmt.Errorf("organization %q is not the current organization %q", locationName, org.Name)
+		}
+		return org.ID, nil
+	}
+
+	var appSpace ApplicationSpaceResponse
+	err := client.Get(ctx, appSpaceImport.path+"/"+url.PathEscape(locationName)+"?organization_id="+org.ID, &appSpace)
+	if err != nil {
+		return "", fmt.Errorf("cannot find application space %q: %w", locationName, err)
+	}
+	return appSpace.ID, nil
 }
 
-// buildReadPath constructs the API path for reading a resource.
-// It supports both:
-//   - Direct ID: data.Id() = "gid:xxx" -> returns "/resource/gid:xxx"
-//   - Name with location: data.Id() = "my-name?location=gid:xxx" -> returns "/resource/my-name?<param>=gid:xxx"
-//     where <param> is translated to the correct API parameter (project_id or organization_id)
-func buildReadPath(resourcePath string, data *schema.ResourceData) string {
-	id := data.Id()
+// buildReadPath constructs the API path for reading a resource, see importSpec.readPath.
+func buildReadPath(spec *importSpec, data *schema.ResourceData) string {
+	return spec.readPath(data.Id())
+}
 
+// readPath constructs the API path for reading a resource.
+// It supports both:
+//   - Direct ID: id = "gid:xxx" -> returns "/resource/gid:xxx"
+//   - Name with location: id = "my-name?location=gid:xxx" -> returns "/resource/my-name?<param>=gid:xxx"
+//     where <param> is the scope query parameter declared by the import spec
+func (s *importSpec) readPath(id string) string {
 	// If the ID contains a query parameter, it's a name+location format
 	// Translate the generic "location" parameter to the correct API parameter
-	if strings.Contains(id, "?location=") {
-		// Determine the correct parameter name based on the resource path
-		var apiParam string
-		switch {
-		case strings.Contains(resourcePath, "/projects"):
-			// Application spaces use organization_id
-			apiParam = "organization_id"
-		case strings.Contains(resourcePath, "/service-accounts"):
-			// Service accounts use organization_id
-			apiParam = "organization_id"
-		default:
-			// Most other resources use project_id (applications, agents, policies, etc.)
-			apiParam = "project_id"
-		}
-
-		// Replace "location=" with the correct API parameter
-		translatedID := strings.Replace(id, "?location=", "?"+apiParam+"=", 1)
-		return resourcePath + "/" + translatedID
+	if s.scopeQueryParam != "" && strings.Contains(id, "?location=") {
+		translatedID := strings.Replace(id, "?location=", "?"+s.scopeQueryParam+"=", 1)
+		return s.path + "/" + translatedID
 	}
 
 	// Otherwise, it's a direct ID
-	return resourcePath + "/" + id
+	return s.path + "/" + id
 }
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import by name", func() {
+	var (
+		mockServer *httptest.Server
+		meta       any
+		requested  []string
+	)
+
+	BeforeEach(func() {
+		requested = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.RequestURI())
+			var resp any
+			switch r.URL.RequestURI() {
+			case "/configs/v1/organizations/current":
+				resp = indykite.CustomerResponse{ID: customerID, Name: "acme"}
+			case "/configs/v1/projects/my-space?organization_id=" + customerID:
+				resp = indykite.ApplicationSpaceResponse{ID: appSpaceID, Name: "my-space"}
+			case "/configs/v1/authorization-policies/my-policy?project_id=" + appSpaceID:
+				resp = indykite.AuthorizationPolicyResponse{ID: sampleID, Name: "my-policy"}
+			case "/configs/v1/service-accounts/my-sa?organization_id=" + customerID:
+				resp = indykite.ServiceAccountResponse{ID: serviceAccountID, Name: "my-sa"}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":5,"message":"not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+
+		provider := indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		meta = provider.Meta()
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	importState := func(resourceType, importID string) (string, error) {
+		res := indykite.Provider().ResourcesMap[resourceType]
+		data := res.Data(&terraform.InstanceState{ID: importID})
+		imported, err := res.Importer.StateContext(context.Background(), data, meta)
+		if err != nil {
+			return "", err
+		}
+		Expect(imported).To(HaveLen(1))
+		return imported[0].Id(), nil
+	}
+
+	It("keeps GID as is without any request", func() {
+		Expect(importState("indykite_authorization_policy", sampleID)).To(Equal(sampleID))
+		Expect(requested).To(BeEmpty())
+	})
+
+	It("rewrites name with location GID to resource GID", func() {
+		Expect(importState("indykite_authorization_policy", "my-policy?location="+appSpaceID)).To(Equal(sampleID))
+		Expect(requested).To(Equal([]string{
+			"/configs/v1/authorization-policies/my-policy?project_id=" + appSpaceID,
+		}))
+	})
+
+	It("translates location of organization scoped resources", func() {
+		Expect(importState("indykite_service_account", "my-sa?location="+customerID)).To(Equal(serviceAccountID))
+	})
+
+	It("resolves application space name", func() {
+		Expect(importState("indykite_authorization_policy", "my-space/my-policy")).To(Equal(sampleID))
+		Expect(requested).To(Equal([]string{
+			"/configs/v1/organizations/current",
+			"/configs/v1/projects/my-space?organization_id=" + customerID,
+			"/configs/v1/authorization-policies/my-policy?project_id=" + appSpaceID,
+		}))
+	})
+
+	It("resolves organization name", func() {
+		Expect(importState("indykite_service_account", "acme/my-sa")).To(Equal(serviceAccountID))
+
+		_, err := importState("indykite_service_account", "other-org/my-sa")
+		Expect(err).To(MatchError(ContainSubstring(`organization "other-org" is not the current organization "acme"`)))
+	})
+
+	It("fails when named entries do not exist", func() {
+		_, err := importState("indykite_authorization_policy", "unknown-space/my-policy")
+		Expect(err).To(MatchError(ContainSubstring(`cannot find application space "unknown-space"`)))
+
+		_, err = importState("indykite_authorization_policy", "unknown?location="+appSpaceID)
+		Expect(err).To(MatchError(ContainSubstring("cannot find unknown?location=" + appSpaceID + " to import")))
+	})
+
+	It("rejects unsupported formats", func() {
+		_, err := importState("indykite_authorization_policy", "my-policy")
+		Expect(err).To(MatchError(ContainSubstring("Unimplemented id format: my-policy")))
+
+		_, err = importState("indykite_authorization_policy", "my-policy?location=my-space")
+		Expect(err).To(MatchError(ContainSubstring("Invalid location in import id")))
+
+		_, err = importState("indykite_service_account_credential", "my-space/my-cred")
+		Expect(err).To(MatchError(ContainSubstring("Expected 'gid:xxx'")))
+	})
+})
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// cypherNodeVariable matches variable of node pattern like "(ln:LicenseNumber)" or "(contract)".
+	cypherNodeVariable = regexp.MustCompile(`\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*[:){]`)
+	// cypherRelationshipVariable matches variable of relationship pattern like "[r1:ACCEPTED]".
+	cypherRelationshipVariable = regexp.MustCompile(`\[\s*([A-Za-z_][A-Za-z0-9_]*)\s*[:\]{*]`)
+)
+
+// policyGraph holds variables the policy cypher defines, which knowledge queries can refer to.
+type policyGraph struct {
+	nodes         map[string]bool
+	relationships map[string]bool
+}
+
+// knowledgeQueryDocument is the part of the knowledge query JSON referring to policy variables.
+type knowledgeQueryDocument struct {
+	Filter              *knowledgeQueryFilter      `json:"filter"`
+	Nodes               []string                   `json:"nodes"`
+	Relationships       []string                   `json:"relationships"`
+	UpsertNodes         []knowledgeQueryUpsertNode `json:"upsert_nodes"`
+	UpsertRelationships []knowledgeQueryUpsertRel  `json:"upsert_relationships"`
+}
+
+type knowledgeQueryFilter struct {
+	Attribute string                 `json:"attribute"`
+	Operands  []knowledgeQueryFilter `json:"operands"`
+}
+
+type knowledgeQueryUpsertNode struct {
+	Name string `json:"name"`
+}
+
+type knowledgeQueryUpsertRel struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// parsePolicyGraph returns variables defined by cypher of the policy.
+// It returns false when the policy has no cypher, so there is nothing to check queries against.
+func parsePolicyGraph(policyJSON string) (policyGraph, bool) {
+	var policy struct {
+		Condition struct {
+			Cypher string `json:"cypher"`
+		} `json:"condition"`
+	}
+	if json.Unmarshal([]byte(policyJSON), &policy) != nil || strings.TrimSpace(policy.Condition.Cypher) == "" {
+		return policyGraph{}, false
+	}
+	graph := policyGraph{nodes: map[string]bool{}, relationships: map[string]bool{}}
+	for _, match := range cypherNodeVariable.FindAllStringSubmatch(policy.Condition.Cypher, -1) {
+		graph.nodes[match[1]] = true
+	}
+	for _, match := range cypherRelationshipVariable.FindAllStringSubmatch(policy.Condition.Cypher, -1) {
+		graph.relationships[match[1]] = true
+	}
+	return graph, true
+}
+
+// checkQueryReferences returns error for every node and relationship variable of the query,
+// which is not defined by the policy. Query, which is not JSON, is left to the schema validation.
+func checkQueryReferences(queryJSON string, graph policyGraph) error {
+	var query knowledgeQueryDocument
+	if json.Unmarshal([]byte(queryJSON), &query) != nil {
+		return nil
+	}
+	// Upserted nodes are new variables, which upserted relationships can connect
+	nodes := make(map[string]bool, len(graph.nodes))
+	for name := range graph.nodes {
+		nodes[name] = true
+	}
+	for _, node := range query.UpsertNodes {
+		nodes[node.Name] = true
+	}
+
+	var errs []error
+	// queryVariable returns the variable of reference like "ln.property.value",
+	// or false for values starting with $, which are input parameters or token claims.
+	queryVariable := func(reference string) (string, bool) {
+		if reference == "" || strings.HasPrefix(reference, "$") {
+			return "", false
+		}
+		variable, _, _ := strings.Cut(reference, ".")
+		return variable, true
+	}
+	checkNode := func(location, reference string) {
+		if variable, ok := queryVariable(reference); ok && !nodes[variable] {
+			errs = append(errs, fmt.Errorf("%s refers to unknown node variable %q, policy defines: %s",
+				location, variable, strings.Join(getMapStringKeys(graph.nodes), ", ")))
+		}
+	}
+	for i, node := range query.Nodes {
+		checkNode(fmt.Sprintf("nodes[%d]", i), node)
+	}
+	for i, rel := range query.Relationships {
+		if variable, ok := queryVariable(rel); ok && !graph.relationships[variable] {
+			errs = append(errs, fmt.Errorf("relationships[%d] refers to unknown relationship variable %q, "+
+				"policy defines: %s", i, variable, strings.Join(getMapStringKeys(graph.relationships), ", ")))
+		}
+	}
+	for i, rel := range query.UpsertRelationships {
+		checkNode(fmt.Sprintf("upsert_relationships[%d].source", i), rel.Source)
+		checkNode(fmt.Sprintf("upsert_relationships[%d].target", i), rel.Target)
+	}
+	var checkFilter func(location string, filter *knowledgeQueryFilter)
+	checkFilter = func(location string, filter *knowledgeQueryFilter) {
+		// Filter can compare properties of both nodes and relationships
+		variable, ok := queryVariable(filter.Attribute)
+		if ok && !nodes[variable] && !graph.relationships[variable] {
+			errs = append(errs, fmt.Errorf("%s.attribute refers to unknown variable %q, "+
+				"policy defines nodes: %s; relationships: %s", location, variable,
+				strings.Join(getMapStringKeys(graph.nodes), ", "),
+				strings.Join(getMapStringKeys(graph.relationships), ", ")))
+		}
+		for i := range filter.Operands {
+			checkFilter(fmt.Sprintf("%s.operands[%d]", location, i), &filter.Operands[i])
+		}
+	}
+	if query.Filter != nil {
+		checkFilter("filter", query.Filter)
+	}
+	return errors.Join(errs...)
+}
+
+// knowledgeQueryCustomizeDiff fetches the referenced policy and checks the query refers only
+// to variables of its cypher, so broken queries are caught at plan time instead of by the backend.
+// Policy, which is not known yet, is created in the same run and cannot be checked.
+// Policy changed in the same run is read in its current version, so skip_policy_check opts out.
+// Unchanged queries are not checked, so plans do not read every policy.
+func knowledgeQueryCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if diff.Get(knowledgeQuerySkipPolicyCheckKey).(bool) {
+		return nil
+	}
+	if diff.Id() != "" && !diff.HasChanges(knowledgeQueryJSONQueryConfigKey, knowledgeQueryPolicyID) {
+		return nil
+	}
+	if !diff.NewValueKnown(knowledgeQueryJSONQueryConfigKey) || !diff.NewValueKnown(knowledgeQueryPolicyID) {
+		return nil
+	}
+	clientCtx, ok := meta.(*ClientContext)
+	if !ok || clientCtx == nil {
+		return nil
+	}
+	policyID := diff.Get(knowledgeQueryPolicyID).(string)
+	var policy AuthorizationPolicyResponse
+	if err := clientCtx.GetClient().Get(ctx, "/authorization-policies/"+policyID, &policy); err != nil {
+		// The check is best effort, missing policy or lacking rights to read it are reported by the backend
+		tflog.Warn(ctx, "Skipping check of knowledge query against policy, which cannot be read", map[string]any{
+			"policy_id": policyID,
+			"error":     err.Error(),
+		})
+		return nil
+	}
+	graph, ok := parsePolicyGraph(policy.Policy)
+	if !ok {
+		return nil
+	}
+	if err := checkQueryReferences(diff.Get(knowledgeQueryJSONQueryConfigKey).(string), graph); err != nil {
+		return fmt.Errorf("query does not match policy %s: %w", policyID, err)
+	}
+	return nil
+}
@@ -16,15 +16,21 @@ package indykite
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type (
 	tfConfig struct {
+		restConfig       *RestClientConfig
 		terraformVersion string
+		// credentialExpiryWarning is the window before credential expiration, when reads warn about it.
+		credentialExpiryWarning time.Duration
 	}
 
 	// ClientContext defines structure returned by ConfigureContextFunc,
@@ -41,10 +47,29 @@ const (
 	clientContextKey contextKey = 1
 )
 
+const (
+	credentialsKey     = "credentials"
+	credentialsFileKey = "credentials_file"
+	baseURLKey         = "base_url"
+	requestTimeoutKey  = "request_timeout"
+	retryKey           = "retry"
+	maxAttemptsKey     = "max_attempts"
+	minBackoffKey      = "min_backoff"
+	maxBackoffKey      = "max_backoff"
+	rateLimitKey       = "rate_limit"
+	requestsPerSecKey  = "requests_per_second"
+	burstKey           = "burst"
+	maxInFlightKey     = "max_in_flight"
+
+	credentialExpiryWarningKey = "credential_expiry_warning"
+)
+
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
 	// The actual provider
 	provider := &schema.Provider{
+		Schema: providerConfigSchema(),
+
 		DataSourcesMap: map[string]*schema.Resource{
 			"indykite_customer":                      dataSourceCustomer(),
 			"indykite_application_space":             dataSourceAppSpace(),
@@ -54,14 +79,22 @@ func Provider() *schema.Provider {
 			"indykite_application_agent":             dataSourceAppAgent(),
 			"indykite_application_agents":            dataSourceAppAgentList(),
 			"indykite_application_agent_credentials": dataSourceAppAgentCredentialList(),
+			"indykite_authorization_policy":          dataSourceAuthorizationPolicy(),
 			"indykite_authorization_policies":        dataSourceAuthorizationPolicyList(),
+			"indykite_token_introspect":              dataSourceTokenIntrospect(),
 			"indykite_token_introspects":             dataSourceTokenIntrospectList(),
+			"indykite_entity_matching_pipeline":      dataSourceEntityMatchingPipeline(),
 			"indykite_entity_matching_pipelines":     dataSourceEntityMatchingPipelineList(),
 			"indykite_event_sinks":                   dataSourceEventSinkList(),
+			"indykite_external_data_resolver":        dataSourceExternalDataResolver(),
 			"indykite_external_data_resolvers":       dataSourceExternalDataResolverList(),
+			"indykite_knowledge_query":               dataSourceKnowledgeQuery(),
 			"indykite_knowledge_queries":             dataSourceKnowledgeQueryList(),
+			"indykite_trust_score_profile":           dataSourceTrustScoreProfile(),
 			"indykite_trust_score_profiles":          dataSourceTrustScoreProfileList(),
+			"indykite_mcp_server":                    dataSourceMCPServer(),
 			"indykite_mcp_servers":                   dataSourceMCPServerList(),
+			"indykite_service_account":               dataSourceServiceAccount(),
 			"indykite_service_accounts":              dataSourceServiceAccountList(),
 			"indykite_service_account_credentials":   dataSourceServiceAccountCredentialList(),
 		},
@@ -77,7 +110,6 @@ func Provider() *schema.Provider {
 			"indykite_entity_matching_pipeline":     resourceEntityMatchingPipeline(),
 			"indykite_knowledge_query":              resourceKnowledgeQuery(),
 			"indykite_trust_score_profile":          resourceTrustScoreProfile(),
-			"indykite_event_sink":                   resourceEventSink(),
 			"indykite_service_account":              resourceServiceAccount(),
 			"indykite_service_account_credential":   resourceServiceAccountCredential(),
 			"indykite_mcp_server":                   resourceMCPServer(),
@@ -85,18 +117,163 @@ func Provider() *schema.Provider {
 	}
 
 	provider.ConfigureContextFunc =
-		func(ctx context.Context, _ *schema.ResourceData) (any, diag.Diagnostics) {
-			return providerConfigure(ctx, provider.TerraformVersion)
+		func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			return providerConfigure(ctx, data, provider.TerraformVersion)
 		}
 
 	return provider
 }
 
+func providerConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		credentialsKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsJSON,
+			Description: "Content of service account credentials file generated from The Hub. " +
+				"Falls back to `INDYKITE_SERVICE_ACCOUNT_CREDENTIALS` environment variable. " +
+				"Takes precedence over `credentials_file`.",
+		},
+		credentialsFileKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description: "Path to service account credentials file generated from The Hub. " +
+				"Falls back to `INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE` environment variable.",
+		},
+		baseURLKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			Description: "Base URL of IndyKite API, for example `https://eu.api.indykite.com`. " +
+				"Overrides `region` and the endpoint stored in credentials.",
+		},
+		regionKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"eu", "us"}, false),
+			Description: "Region of IndyKite API to use, one of `eu` or `us`. " +
+				"Overrides the endpoint stored in credentials.",
+		},
+		requestTimeoutKey: {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "2m",
+			ValidateDiagFunc: ValidateDuration,
+			Description:      "Timeout of a single HTTP request to IndyKite API, as Go duration like `90s` or `2m`.",
+		},
+		retryKey: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Description: "Retry policy of requests to IndyKite API. `GET`, `PUT` and `DELETE` requests are retried " +
+				"on HTTP 429, 5xx and connection errors. Creating `POST` requests are retried only on HTTP 429, " +
+				"so a lost response never creates a duplicate. " +
+				"`Retry-After` response header is honoured up to `max_backoff`.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				maxAttemptsKey: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryMaxAttempts,
+					ValidateFunc: validation.IntBetween(1, 10),
+					Description:  "Total number of attempts including the first one. Set `1` to disable retries.",
+				},
+				minBackoffKey: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          defaultRetryMinBackoff.String(),
+					ValidateDiagFunc: ValidateDuration,
+					Description:      "Minimal wait before a retry, doubled with each attempt and randomized by jitter.",
+				},
+				maxBackoffKey: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          defaultRetryMaxBackoff.String(),
+					ValidateDiagFunc: ValidateDuration,
+					Description:      "Maximal wait between attempts, also caps the wait requested by `Retry-After`.",
+				},
+			}},
+		},
+		credentialExpiryWarningKey: {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: ValidateDuration,
+			Description: "Warn when reading credentials expiring within this duration, like `720h`. " +
+				"Applies to credential resources and lists of credentials. Not set by default.",
+		},
+		rateLimitKey: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Description: "Client-side throttling of requests to IndyKite API, shared by all resources of the provider. " +
+				"Each HTTP 429 response halves the request rate, which then recovers gradually on success.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				requestsPerSecKey: {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      defaultRequestsPerSecond,
+					ValidateFunc: validation.FloatAtLeast(0.1),
+					Description:  "Steady number of requests per second.",
+				},
+				burstKey: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRateBurst,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of requests which can be sent at once above the steady rate.",
+				},
+				maxInFlightKey: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultMaxInFlight,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Maximal number of requests waiting for response at the same time.",
+				},
+			}},
+		},
+	}
+}
+
 func providerConfigure(
 	ctx context.Context,
+	data *schema.ResourceData,
 	version string,
 ) (any, diag.Diagnostics) {
-	cfg := &tfConfig{terraformVersion: version}
+	restConfig := &RestClientConfig{
+		Credentials:     data.Get(credentialsKey).(string),
+		CredentialsFile: data.Get(credentialsFileKey).(string),
+		BaseURL:         data.Get(baseURLKey).(string),
+		Region:          data.Get(regionKey).(string),
+	}
+	if timeout, err := time.ParseDuration(data.Get(requestTimeoutKey).(string)); err == nil {
+		restConfig.RequestTimeout = timeout
+	}
+	if retry, ok := data.Get(retryKey).([]any); ok && len(retry) > 0 && retry[0] != nil {
+		restConfig.Retry = getRetryConfig(retry[0].(map[string]any))
+		if restConfig.Retry.MinBackoff > restConfig.Retry.MaxBackoff {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Invalid retry configuration",
+				Detail: fmt.Sprintf("%s %s must not be greater than %s %s",
+					minBackoffKey, restConfig.Retry.MinBackoff, maxBackoffKey, restConfig.Retry.MaxBackoff),
+				AttributePath: cty.GetAttrPath(retryKey).IndexInt(0).GetAttr(minBackoffKey),
+			}}
+		}
+	}
+	if rateLimit, ok := data.Get(rateLimitKey).([]any); ok && len(rateLimit) > 0 && rateLimit[0] != nil {
+		limits := rateLimit[0].(map[string]any)
+		restConfig.RateLimit = RateLimitConfig{
+			RequestsPerSecond: limits[requestsPerSecKey].(float64),
+			Burst:             limits[burstKey].(int),
+			MaxInFlight:       limits[maxInFlightKey].(int),
+		}
+	}
+
+	cfg := &tfConfig{restConfig: restConfig, terraformVersion: version}
+	if window, err := time.ParseDuration(data.Get(credentialExpiryWarningKey).(string)); err == nil {
+		cfg.credentialExpiryWarning = window
+	}
 	c, diags := cfg.getConfigClient(ctx) // Rename 'err' to 'diags' for clarity
 	if diags.HasError() {
 		return nil, diags
@@ -107,6 +284,18 @@ func providerConfigure(
 	}, diags // Return diagnostics even if they contain only warnings
 }
 
+// getRetryConfig converts retry block into RetryConfig, invalid durations are left to defaults.
+func getRetryConfig(retry map[string]any) RetryConfig {
+	cfg := RetryConfig{MaxAttempts: retry[maxAttemptsKey].(int)}
+	if d, err := time.ParseDuration(retry[minBackoffKey].(string)); err == nil {
+		cfg.MinBackoff = d
+	}
+	if d, err := time.ParseDuration(retry[maxBackoffKey].(string)); err == nil {
+		cfg.MaxBackoff = d
+	}
+	return cfg
+}
+
 // getClientContext converts meta into ClientContext structure.
 func getClientContext(d *diag.Diagnostics, meta any) *ClientContext {
 	clientCtx, ok := meta.(*ClientContext)
@@ -127,14 +316,14 @@ func WithClient(ctx context.Context, c *RestClient) context.Context {
 }
 
 // getConfigClient configures and returns a fully initialized REST client.
-func (*tfConfig) getConfigClient(ctx context.Context) (*RestClient, diag.Diagnostics) {
+func (cfg *tfConfig) getConfigClient(ctx context.Context) (*RestClient, diag.Diagnostics) {
 	if client, ok := ctx.Value(clientContextKey).(*RestClient); ok {
 		return client, nil
 	}
 
 	// This can be called multiple times, because it is called from ConfigureContextFunc,
-	// which is called for each resource.
-	client, err := NewRestClient(ctx)
+	// which is called for each provider instance. Instances sharing settings share the client.
+	client, err := sharedClients.get(ctx, cfg.restConfig)
 	if err != nil {
 		return nil, diag.Diagnostics{{
 			Severity: diag.Error,
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	provschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider serves resources built on terraform-plugin-framework.
+// It is muxed with the SDK provider, which is configured first, and shares its client.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+// ProviderServerFactory returns factory of the protocol v6 server, which muxes the SDK provider
+// with resources already migrated to terraform-plugin-framework.
+// Both share the configuration and the client of sdkProvider.
+func ProviderServerFactory(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov6.ProviderServer, error) {
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return schema.NewGRPCProviderServer(sdkProvider)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		// SDK provider must be first, muxed servers are configured in the order
+		func() tfprotov6.ProviderServer { return upgradedSdkServer },
+		providerserver.NewProtocol6(&frameworkProvider{sdkProvider: sdkProvider}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+func (*frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "indykite"
+}
+
+// Schema must be identical to the SDK provider schema, mux refuses to serve them otherwise.
+// Values are validated by the SDK provider only.
+func (*frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes, blocks := frameworkProviderSchema(providerConfigSchema())
+	resp.Schema = provschema.Schema{Attributes: attributes, Blocks: blocks}
+}
+
+func (p *frameworkProvider) Configure(
+	_ context.Context,
+	_ provider.ConfigureRequest,
+	resp *provider.ConfigureResponse,
+) {
+	clientCtx := frameworkClientContext(&resp.Diagnostics, p.sdkProvider.Meta())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.ResourceData = clientCtx
+	resp.DataSourceData = clientCtx
+	resp.EphemeralResourceData = clientCtx
+}
+
+func (*frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newEventSinkResource,
+	}
+}
+
+func (*frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newEventSinkDataSource,
+	}
+}
+
+func (*frameworkProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAppAgentCredentialEphemeral,
+	}
+}
+
+func (*frameworkProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		newValidatePolicyFunction,
+	}
+}
+
+// frameworkProviderSchema converts SDK provider schema into framework schema.
+// Lists of resources are blocks as SDK serves them.
+func frameworkProviderSchema(
+	sdkSchema map[string]*schema.Schema,
+) (map[string]provschema.Attribute, map[string]provschema.Block) {
+	attributes := make(map[string]provschema.Attribute)
+	blocks := make(map[string]provschema.Block)
+	for key, s := range sdkSchema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[key] = provschema.StringAttribute{
+				Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description,
+			}
+		case schema.TypeBool:
+			attributes[key] = provschema.BoolAttribute{
+				Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description,
+			}
+		case schema.TypeInt:
+			attributes[key] = provschema.Int64Attribute{
+				Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description,
+			}
+		case schema.TypeFloat:
+			attributes[key] = provschema.Float64Attribute{
+				Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description,
+			}
+		case schema.TypeList:
+			if elem, ok := s.Elem.(*schema.Resource); ok {
+				nestedAttributes, nestedBlocks := frameworkProviderSchema(elem.Schema)
+				blocks[key] = provschema.ListNestedBlock{
+					Description: s.Description,
+					NestedObject: provschema.NestedBlockObject{
+						Attributes: nestedAttributes,
+						Blocks:     nestedBlocks,
+					},
+				}
+			}
+		}
+	}
+	return attributes, blocks
+}
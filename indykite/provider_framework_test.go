@@ -0,0 +1,339 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Muxed provider server", func() {
+	var (
+		mockServer *httptest.Server
+		server     tfprotov6.ProviderServer
+		schemas    *tfprotov6.GetProviderSchemaResponse
+		posted     map[string]any
+		ctx        = context.Background()
+	)
+
+	BeforeEach(func() {
+		sink := indykite.EventSinkResponse{
+			ID: sampleID, CustomerID: customerID, AppSpaceID: appSpaceID, Name: "my-sink", DisplayName: "my-sink",
+			Etag: "etag-1",
+			Config: map[string]any{
+				"providers": map[string]any{
+					"kafka2": map[string]any{"kafka": map[string]any{
+						"brokers": []string{"my.kafka.server.example.com:9092"}, "topic": "my-kafka-topic",
+						"username": "my-username", "lastError": "broker unreachable",
+					}},
+				},
+				"routes": []any{map[string]any{
+					"providerId": "kafka2",
+					"keysValues": map[string]any{"eventType": "indykite.audit.config.create"},
+				}},
+			},
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.RequestURI() {
+			case "/configs/v1/event-sinks":
+				Expect(json.NewDecoder(r.Body).Decode(&posted)).To(Succeed())
+				_ = json.NewEncoder(w).Encode(indykite.EventSinkResponse{ID: sampleID})
+			case "/configs/v1/event-sinks/" + sampleID, "/configs/v1/event-sinks/my-sink?project_id=" + appSpaceID:
+				_ = json.NewEncoder(w).Encode(sink)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":5,"message":"not found"}`))
+			}
+		}))
+
+		server, schemas = configuredMuxServer(mockServer)
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	resourceValue := func(value map[string]any) *tfprotov6.DynamicValue {
+		typ := schemas.ResourceSchemas["indykite_event_sink"].ValueType()
+		dv, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, value))
+		Expect(err).To(Succeed())
+		return &dv
+	}
+	plain := func(typ tftypes.Type, dv *tfprotov6.DynamicValue) map[string]any {
+		value, err := dv.Unmarshal(typ)
+		Expect(err).To(Succeed())
+		result, _ := indykite.StateValue(value).(map[string]any)
+		return result
+	}
+
+	It("serves resources of both SDK and framework providers", func() {
+		Expect(schemas.ResourceSchemas).To(HaveKey("indykite_event_sink"))
+		Expect(schemas.ResourceSchemas).To(HaveKey("indykite_application_space"))
+		Expect(schemas.DataSourceSchemas).To(HaveKey("indykite_event_sink"))
+		Expect(schemas.DataSourceSchemas).To(HaveKey("indykite_event_sinks"))
+	})
+
+	It("reads event sink and keeps sensitive values of prior state", func() {
+		resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "indykite_event_sink",
+			CurrentState: resourceValue(map[string]any{
+				"id": sampleID, "location": appSpaceID, "name": "my-sink",
+				"providers": []any{map[string]any{
+					"provider_name": "kafka2",
+					"kafka": []any{map[string]any{
+						"brokers": []any{"old.example.com:9092"}, "topic": "old-topic",
+						"username": "my-username", "password": "secret",
+					}},
+				}},
+			}),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		state := plain(schemas.ResourceSchemas["indykite_event_sink"].ValueType(), resp.NewState)
+		Expect(state).To(HaveKeyWithValue("etag", "etag-1"))
+		Expect(state).To(HaveKeyWithValue("app_space_id", appSpaceID))
+		Expect(state).To(HaveKeyWithValue("display_name", BeNil()))
+		kafka := state["providers"].([]any)[0].(map[string]any)["kafka"].([]any)[0].(map[string]any)
+		Expect(kafka).To(HaveKeyWithValue("password", "secret"))
+		Expect(kafka).To(HaveKeyWithValue("topic", "my-kafka-topic"))
+		Expect(kafka).To(HaveKeyWithValue("last_error", "broker unreachable"))
+		Expect(kafka).To(HaveKeyWithValue("brokers", []any{"my.kafka.server.example.com:9092"}))
+		route := state["routes"].([]any)[0].(map[string]any)
+		Expect(route).To(HaveKeyWithValue("provider_id", "kafka2"))
+		Expect(route["keys_values_filter"]).To(ConsistOf(
+			HaveKeyWithValue("event_type", "indykite.audit.config.create")))
+	})
+
+	It("imports event sink by name with location", func() {
+		resp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+			TypeName: "indykite_event_sink",
+			ID:       "my-sink?location=" + appSpaceID,
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(resp.ImportedResources).To(HaveLen(1))
+		state := plain(schemas.ResourceSchemas["indykite_event_sink"].ValueType(), resp.ImportedResources[0].State)
+		Expect(state).To(HaveKeyWithValue("id", sampleID))
+	})
+
+	It("rejects provider without exactly one sink", func() {
+		resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+			TypeName: "indykite_event_sink",
+			Config: resourceValue(map[string]any{
+				"location": appSpaceID, "name": "my-sink",
+				"providers": []any{map[string]any{"provider_name": "kafka2"}},
+				"routes":    []any{map[string]any{"provider_id": "kafka2"}},
+			}),
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(ContainElement(HaveField("Detail",
+			"exactly one of providers must be specified in providers[0]")))
+	})
+
+	It("requires exactly one of secret and its write-only variant", func() {
+		validate := func(kafka map[string]any) []*tfprotov6.Diagnostic {
+			kafka["brokers"] = []any{"my.kafka.server.example.com:9092"}
+			kafka["topic"] = "my-kafka-topic"
+			kafka["username"] = "my-username"
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "indykite_event_sink",
+				Config: resourceValue(map[string]any{
+					"location": appSpaceID, "name": "my-sink",
+					"providers": []any{map[string]any{"provider_name": "kafka2", "kafka": []any{kafka}}},
+					"routes":    []any{map[string]any{"provider_id": "kafka2"}},
+				}),
+				ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+					WriteOnlyAttributesAllowed: true,
+				},
+			})
+			Expect(err).To(Succeed())
+			return resp.Diagnostics
+		}
+
+		Expect(validate(map[string]any{"password_wo": "secret", "password_wo_version": 1})).To(BeEmpty())
+		Expect(validate(map[string]any{"password": "secret"})).To(BeEmpty())
+		Expect(validate(map[string]any{})).To(ContainElement(HaveField("Summary", "Invalid Attribute Combination")))
+		Expect(validate(map[string]any{"password": "secret", "password_wo": "secret"})).To(
+			ContainElement(HaveField("Summary", "Invalid Attribute Combination")))
+		Expect(validate(map[string]any{"password": "secret", "password_wo_version": 1})).To(
+			ContainElement(HaveField("Summary", "Invalid Attribute Combination")))
+	})
+
+	It("sends write-only secret without storing it in the state", func() {
+		config := resourceValue(map[string]any{
+			"location": appSpaceID, "name": "my-sink",
+			"providers": []any{map[string]any{
+				"provider_name": "kafka2",
+				"kafka": []any{map[string]any{
+					"brokers": []any{"my.kafka.server.example.com:9092"}, "topic": "my-kafka-topic",
+					"username": "my-username", "password_wo": "secret", "password_wo_version": 1,
+				}},
+			}},
+			"routes": []any{map[string]any{"provider_id": "kafka2"}},
+		})
+		typ := schemas.ResourceSchemas["indykite_event_sink"].ValueType()
+		prior, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+		Expect(err).To(Succeed())
+
+		planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: "indykite_event_sink", PriorState: &prior, ProposedNewState: config, Config: config,
+		})
+		Expect(err).To(Succeed())
+		Expect(planResp.Diagnostics).To(BeEmpty())
+		applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: "indykite_event_sink", PriorState: &prior, PlannedState: planResp.PlannedState, Config: config,
+		})
+		Expect(err).To(Succeed())
+		Expect(applyResp.Diagnostics).To(BeEmpty())
+
+		Expect(posted).To(HaveKeyWithValue("providers", HaveKeyWithValue("kafka2",
+			HaveKeyWithValue("kafka", HaveKeyWithValue("password", "secret")))))
+		kafka := plain(typ, applyResp.NewState)["providers"].([]any)[0].(map[string]any)["kafka"].([]any)[0]
+		Expect(kafka).To(HaveKeyWithValue("password_wo", BeNil()))
+		Expect(kafka).To(HaveKeyWithValue("password", BeNil()))
+		Expect(kafka).To(HaveKeyWithValue("password_wo_version", 1))
+	})
+
+	It("reads event sink data source by name", func() {
+		typ := schemas.DataSourceSchemas["indykite_event_sink"].ValueType()
+		config, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, map[string]any{
+			"name": "my-sink", "location": appSpaceID, "timeouts": map[string]any{"read": "5m"},
+		}))
+		Expect(err).To(Succeed())
+
+		resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+			TypeName: "indykite_event_sink",
+			Config:   &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(BeEmpty())
+		state := plain(typ, resp.State)
+		Expect(state).To(HaveKeyWithValue("id", sampleID))
+		Expect(state["providers"]).To(ConsistOf(HaveKeyWithValue("provider_name", "kafka2")))
+		Expect(state["timeouts"]).To(HaveKeyWithValue("read", "5m"))
+	})
+
+	It("leaves write-only attributes out of event sink data source", func() {
+		providers := schemas.DataSourceSchemas["indykite_event_sink"].Block.Attributes
+		var kafka *tfprotov6.SchemaObject
+		for _, attr := range providers {
+			if attr.Name == "providers" {
+				for _, nested := range attr.NestedType.Attributes {
+					if nested.Name == "kafka" {
+						kafka = nested.NestedType
+					}
+				}
+			}
+		}
+		Expect(kafka).NotTo(BeNil())
+		names := []string{}
+		for _, attr := range kafka.Attributes {
+			names = append(names, attr.Name)
+		}
+		Expect(names).To(ContainElements("password", "username", "topic"))
+		Expect(names).NotTo(ContainElements("password_wo", "password_wo_version"))
+	})
+
+	It("fails event sink data source when entry is missing", func() {
+		typ := schemas.DataSourceSchemas["indykite_event_sink"].ValueType()
+		config, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, map[string]any{"id": customerID}))
+		Expect(err).To(Succeed())
+
+		resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+			TypeName: "indykite_event_sink",
+			Config:   &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(resp.Diagnostics).To(ContainElement(HaveField("Summary", "Data source lookup did not find any entry")))
+	})
+
+	It("validates policy with provider function", func() {
+		Expect(schemas.Functions).To(HaveKey("validate_policy"))
+		validatePolicy := func(policyJSON string) any {
+			arg, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, policyJSON))
+			Expect(err).To(Succeed())
+			resp, err := server.CallFunction(ctx, &tfprotov6.CallFunctionRequest{
+				Name: "validate_policy", Arguments: []*tfprotov6.DynamicValue{&arg},
+			})
+			Expect(err).To(Succeed())
+			Expect(resp.Error).To(BeNil())
+			result, err := resp.Result.Unmarshal(tftypes.Bool)
+			Expect(err).To(Succeed())
+			return indykite.StateValue(result)
+		}
+
+		Expect(validatePolicy(`{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Person"},` +
+			`"actions":["CAN_DRIVE"],"resource":{"type":"Car"}}`)).To(BeTrue())
+		Expect(validatePolicy(`{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Person"}}`)).To(BeFalse())
+		Expect(validatePolicy("not valid json")).To(BeFalse())
+	})
+})
+
+// configuredMuxServer returns the muxed provider server configured to use the mock server.
+func configuredMuxServer(mockServer *httptest.Server) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	ctx := context.Background()
+	server, err := indykitetest.ProtoV6ProviderFactories(indykite.Provider())["indykite"]()
+	Expect(err).To(Succeed())
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	Expect(err).To(Succeed())
+	Expect(schemas.Diagnostics).To(BeEmpty())
+
+	providerType := schemas.Provider.ValueType()
+	config, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, nil))
+	Expect(err).To(Succeed())
+	client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+	configResp, err := server.ConfigureProvider(indykite.WithClient(ctx, client),
+		&tfprotov6.ConfigureProviderRequest{Config: &config})
+	Expect(err).To(Succeed())
+	Expect(configResp.Diagnostics).To(BeEmpty())
+	return server, schemas
+}
+
+// protoValue builds value of given type from plain Go values, missing attributes are null.
+func protoValue(typ tftypes.Type, value any) tftypes.Value {
+	if value == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	switch t := typ.(type) {
+	case tftypes.Object:
+		attributes, _ := value.(map[string]any)
+		values := make(map[string]tftypes.Value, len(t.AttributeTypes))
+		for key, attrType := range t.AttributeTypes {
+			values[key] = protoValue(attrType, attributes[key])
+		}
+		return tftypes.NewValue(t, values)
+	case tftypes.List:
+		items, _ := value.([]any)
+		values := make([]tftypes.Value, len(items))
+		for i, item := range items {
+			values[i] = protoValue(t.ElementType, item)
+		}
+		return tftypes.NewValue(t, values)
+	}
+	return tftypes.NewValue(typ, value)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provider configuration", func() {
+	const tokenCredentials = `{"token":"static-token","baseUrl":"https://unused.example.com"}`
+	var (
+		mockServer *httptest.Server
+		gotPath    string
+		gotAuth    string
+	)
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS", "")
+		GinkgoT().Setenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE", "")
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotAuth = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"` + sampleID + `"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	It("has valid schema", func() {
+		Expect(indykite.Provider().InternalValidate()).To(Succeed())
+	})
+
+	It("uses credentials and base_url from configuration", func() {
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			Credentials: tokenCredentials,
+			BaseURL:     mockServer.URL,
+		})
+		Expect(err).To(Succeed())
+
+		var resp indykite.ApplicationResponse
+		Expect(client.Get(context.Background(), "/applications/"+sampleID, &resp)).To(Succeed())
+		Expect(resp.ID).To(Equal(sampleID))
+		Expect(gotPath).To(Equal("/configs/v1/applications/" + sampleID))
+		Expect(gotAuth).To(Equal("Bearer static-token"))
+	})
+
+	It("reads credentials_file when credentials are not set", func() {
+		credsFile := filepath.Join(GinkgoT().TempDir(), "credentials.json")
+		Expect(os.WriteFile(credsFile, []byte(tokenCredentials), 0o600)).To(Succeed())
+
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			CredentialsFile: credsFile,
+			BaseURL:         mockServer.URL + "/configs/v1",
+		})
+		Expect(err).To(Succeed())
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(gotPath).To(Equal("/configs/v1/projects/" + sampleID))
+	})
+
+	It("falls back to environment variables", func() {
+		GinkgoT().Setenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS", tokenCredentials)
+
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			BaseURL: mockServer.URL,
+		})
+		Expect(err).To(Succeed())
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(gotAuth).To(Equal("Bearer static-token"))
+	})
+
+	It("rejects min_backoff greater than max_backoff", func() {
+		diags := indykite.Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{
+			"credentials": tokenCredentials,
+			"base_url":    mockServer.URL,
+			"retry":       []any{map[string]any{"min_backoff": "1m", "max_backoff": "10s"}},
+		}))
+		Expect(diags).To(ConsistOf(And(
+			HaveField("Severity", diag.Error),
+			HaveField("Detail", "min_backoff 1m0s must not be greater than max_backoff 10s"),
+			HaveField("AttributePath", cty.GetAttrPath("retry").IndexInt(0).GetAttr("min_backoff")),
+		)))
+	})
+
+	It("fails without any credentials", func() {
+		_, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{})
+		Expect(err).To(MatchError(ContainSubstring("credentials or credentials_file must be set")))
+	})
+
+	Describe("client registry", func() {
+		configure := func(raw map[string]any) *indykite.RestClient {
+			provider := indykite.Provider()
+			d := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+			Expect(d.HasError()).To(BeFalse(), "%v", d)
+			clientCtx, ok := provider.Meta().(*indykite.ClientContext)
+			Expect(ok).To(BeTrue())
+			return clientCtx.GetClient()
+		}
+
+		It("shares client between provider instances with the same settings", func() {
+			first := configure(map[string]any{"credentials": tokenCredentials, "base_url": mockServer.URL})
+			second := configure(map[string]any{"credentials": tokenCredentials, "base_url": mockServer.URL})
+			Expect(second).To(BeIdenticalTo(first))
+
+			otherRegion := configure(map[string]any{"credentials": tokenCredentials, "region": "us"})
+			Expect(otherRegion).NotTo(BeIdenticalTo(first))
+			otherTimeout := configure(map[string]any{
+				"credentials":     tokenCredentials,
+				"base_url":        mockServer.URL,
+				"request_timeout": "30s",
+			})
+			Expect(otherTimeout).NotTo(BeIdenticalTo(first))
+		})
+
+		It("uses registered client for matching provider alias", func() {
+			euClient := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			unregister, err := indykite.RegisterClient(&indykite.RestClientConfig{
+				Credentials:    tokenCredentials,
+				Region:         "eu",
+				RequestTimeout: 2 * time.Minute,
+			}, euClient)
+			Expect(err).To(Succeed())
+			DeferCleanup(unregister)
+
+			Expect(configure(map[string]any{"credentials": tokenCredentials, "region": "eu"})).
+				To(BeIdenticalTo(euClient))
+			Expect(configure(map[string]any{"credentials": tokenCredentials, "region": "us"})).
+				NotTo(BeIdenticalTo(euClient))
+		})
+	})
+})
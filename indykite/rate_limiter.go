@@ -0,0 +1,134 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Defaults of client-side throttling. Terraform runs 10 operations in parallel by default.
+const (
+	defaultRequestsPerSecond = 10
+	defaultRateBurst         = 20
+	defaultMaxInFlight       = 10
+	// rateDecreaseFactor multiplies the current rate on every 429 response.
+	rateDecreaseFactor = 0.5
+	// rateMinFraction is the lowest fraction of configured rate the adaptation can go down to.
+	rateMinFraction = 0.05
+	// rateIncreaseSteps is how many successful responses restore the configured rate from zero.
+	rateIncreaseSteps = 20
+)
+
+// RateLimitConfig describes client-side throttling of requests.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the steady rate of the token bucket.
+	RequestsPerSecond float64
+	// Burst is the size of the token bucket, so short bursts are not slowed down.
+	Burst int
+	// MaxInFlight caps how many requests wait for response at the same time.
+	MaxInFlight int
+}
+
+// withDefaults returns a copy of the config with zero values replaced by defaults.
+func (r RateLimitConfig) withDefaults() RateLimitConfig {
+	if r.RequestsPerSecond <= 0 {
+		r.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	if r.Burst <= 0 {
+		r.Burst = defaultRateBurst
+	}
+	if r.MaxInFlight <= 0 {
+		r.MaxInFlight = defaultMaxInFlight
+	}
+	return r
+}
+
+// throttledTransport limits the rate and concurrency of requests sent through the wrapped transport.
+// It sits below go-retryablehttp, so every retry attempt is throttled as well.
+// The rate adapts to the backend: every 429 response halves it, and successful responses
+// restore it step by step back to the configured value (AIMD).
+type throttledTransport struct {
+	next     http.RoundTripper
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+	maxRate  rate.Limit
+	mu       sync.Mutex
+}
+
+func newThrottledTransport(next http.RoundTripper, cfg RateLimitConfig) *throttledTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &throttledTransport{
+		next:     next,
+		limiter:  rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+		inFlight: make(chan struct{}, cfg.MaxInFlight),
+		maxRate:  rate.Limit(cfg.RequestsPerSecond),
+	}
+}
+
+// RoundTrip waits for a rate token and a free in-flight slot, then sends the request.
+// The slot is held until the response body is closed.
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	select {
+	case t.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.inFlight
+		return nil, err
+	}
+	t.adapt(resp.StatusCode)
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-t.inFlight }}
+	return resp, nil
+}
+
+// adapt decreases the rate multiplicatively on 429 and increases it additively on success.
+func (t *throttledTransport) adapt(statusCode int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.limiter.Limit()
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		t.limiter.SetLimit(max(current*rateDecreaseFactor, t.maxRate*rateMinFraction))
+	case statusCode < 400 && current < t.maxRate:
+		t.limiter.SetLimit(min(current+t.maxRate/rateIncreaseSteps, t.maxRate))
+	}
+}
+
+// releaseOnClose calls release exactly once, when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -31,10 +31,8 @@ func resourceApplication() *schema.Resource {
 		ReadContext:   resApplicationRead,
 		UpdateContext: resApplicationUpdate,
 		DeleteContext: resApplicationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
-		Timeouts: defaultTimeouts(),
+		Importer:      applicationImport.importer(),
+		Timeouts:      defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			customerIDKey:         setComputed(customerIDSchema()),
 			appSpaceIDKey:         appSpaceIDSchema(),
@@ -43,6 +41,7 @@ func resourceApplication() *schema.Resource {
 			descriptionKey:        descriptionSchema(),
 			createTimeKey:         createTimeSchema(),
 			updateTimeKey:         updateTimeSchema(),
+			etagKey:               etagSchema(),
 			deletionProtectionKey: deletionProtectionSchema(),
 		},
 	}
@@ -85,7 +84,7 @@ func resApplicationRead(ctx context.Context, data *schema.ResourceData, meta any
 
 	var resp ApplicationResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/applications", data)
+	path := buildReadPath(applicationImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -99,6 +98,7 @@ func resApplicationRead(ctx context.Context, data *schema.ResourceData, meta any
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	return d
 }
 
@@ -122,7 +122,7 @@ func resApplicationUpdate(ctx context.Context, data *schema.ResourceData, meta a
 	}
 
 	var resp ApplicationResponse
-	err := clientCtx.GetClient().Put(ctx, "/applications/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(ctx, "/applications/"+data.Id(), data.Get(etagKey).(string), req, &resp)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -140,7 +140,7 @@ func resApplicationDelete(ctx context.Context, data *schema.ResourceData, meta a
 	if hasDeleteProtection(&d, data) {
 		return d
 	}
-	err := clientCtx.GetClient().Delete(ctx, "/applications/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/applications/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
@@ -42,10 +42,8 @@ func resourceApplicationAgent() *schema.Resource {
 		ReadContext:   resAppAgentRead,
 		UpdateContext: resAppAgentUpdate,
 		DeleteContext: resAppAgentDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
-		Timeouts: defaultTimeouts(),
+		Importer:      appAgentImport.importer(),
+		Timeouts:      defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			customerIDKey:         setComputed(customerIDSchema()),
 			appSpaceIDKey:         setComputed(appSpaceIDSchema()),
@@ -55,6 +53,7 @@ func resourceApplicationAgent() *schema.Resource {
 			descriptionKey:        descriptionSchema(),
 			createTimeKey:         createTimeSchema(),
 			updateTimeKey:         updateTimeSchema(),
+			etagKey:               etagSchema(),
 			deletionProtectionKey: deletionProtectionSchema(),
 			apiPermissionsKey:     apiPermissionsSchema(),
 		},
@@ -110,7 +109,7 @@ func resAppAgentRead(ctx context.Context, data *schema.ResourceData, meta any) d
 
 	var resp ApplicationAgentResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/application-agents", data)
+	path := buildReadPath(appAgentImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -125,6 +124,7 @@ func resAppAgentRead(ctx context.Context, data *schema.ResourceData, meta any) d
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, apiPermissionsKey, resp.APIPermissions)
 	return d
 }
@@ -151,7 +151,9 @@ func resAppAgentUpdate(ctx context.Context, data *schema.ResourceData, meta any)
 	}
 
 	var resp ApplicationAgentResponse
-	err := clientCtx.GetClient().Put(ctx, "/application-agents/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/application-agents/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -169,7 +171,7 @@ func resAppAgentDelete(ctx context.Context, data *schema.ResourceData, meta any)
 	if hasDeleteProtection(&d, data) {
 		return d
 	}
-	err := clientCtx.GetClient().Delete(ctx, "/application-agents/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/application-agents/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
@@ -16,6 +16,17 @@ package indykite
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -24,14 +35,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 )
 
 const (
-	publicKeyPEMKey = "public_key_pem"
-	publicKeyJWKKey = "public_key_jwk"
-	expireTimeKey   = "expire_time"
-	kidKey          = "kid"
-	agentConfigKey  = "agent_config"
+	publicKeyPEMKey   = "public_key_pem"
+	publicKeyJWKKey   = "public_key_jwk"
+	expireTimeKey     = "expire_time"
+	kidKey            = "kid"
+	agentConfigKey    = "agent_config"
+	privateKeyFileKey = "private_key_file"
+
+	// privateKeyJWKField is the field of agent configuration holding the private key.
+	privateKeyJWKField = "privateKeyJWK"
 )
 
 // A freshly created application agent may not be immediately usable by the
@@ -58,16 +75,21 @@ var (
 	credCreateRetryWaitMax = 2 * time.Second
 )
 
+var appAgentCredRotation = credentialRotation{
+	path:      "/application-agent-credentials/",
+	configKey: agentConfigKey,
+}
+
 func resourceApplicationAgentCredential() *schema.Resource {
-	return &schema.Resource{
+	res := &schema.Resource{
 		Description:   "App agent credentials is a JSON configuration file that contains a secret key or token. ",
 		CreateContext: resAppAgentCredCreate,
 		ReadContext:   resAppAgentCredRead,
+		UpdateContext: resAppAgentCredUpdate,
 		DeleteContext: resAppAgentCredDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
-		Timeouts: defaultTimeouts("update"),
+		CustomizeDiff: appAgentCredRotation.customizeDiff,
+		Importer:      appAgentCredentialImport.importer(),
+		Timeouts:      defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			customerIDKey:    setComputed(customerIDSchema()),
 			appSpaceIDKey:    setComputed(appSpaceIDSchema()),
@@ -91,7 +113,7 @@ func resourceApplicationAgentCredential() *schema.Resource {
 				Optional:      true,
 				ForceNew:      true,
 				Deprecated:    "This field is deprecated.",
-				ConflictsWith: []string{publicKeyJWKKey},
+				ConflictsWith: []string{publicKeyJWKKey, privateKeyFileKey},
 				ValidateFunc: validation.All(
 					validation.StringMatch(
 						// \s* is required, because Terraform does not trim inputs before validation
@@ -107,7 +129,7 @@ func resourceApplicationAgentCredential() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				Deprecated:       "This field is deprecated.",
-				ConflictsWith:    []string{publicKeyPEMKey},
+				ConflictsWith:    []string{publicKeyPEMKey, privateKeyFileKey},
 				DiffSuppressFunc: structure.SuppressJsonDiff,
 				ValidateFunc:     validation.All(validation.StringIsJSON, validation.StringLenBetween(96, 8192)),
 				Description:      "Provide your onw Public key in JWK format, otherwise new pair is generated",
@@ -115,16 +137,31 @@ func resourceApplicationAgentCredential() *schema.Resource {
 			expireTimeKey: {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
+				Computed:         true,
 				ValidateFunc:     validation.IsRFC3339Time,
 				DiffSuppressFunc: ExpireTimeDiffSuppress,
-				Description:      "Optional date-time when credentials are going to expire",
+				Description:      "Optional date-time when credentials are going to expire, any change replaces the credential",
+			},
+			privateKeyFileKey: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{publicKeyPEMKey, publicKeyJWKKey},
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Description: "Path of the local file, where agent configuration with EC P-256 private key " +
+					"generated by the provider is written with 0600 permissions. Only the public key is uploaded, " +
+					"and the private key is never stored in the state. The file is rewritten on rotation " +
+					"and removed on destroy.",
 			},
-			kidKey:         {Type: schema.TypeString, Computed: true},
-			agentConfigKey: {Type: schema.TypeString, Computed: true, Sensitive: true},
-			createTimeKey:  createTimeSchema(),
+			kidKey:             {Type: schema.TypeString, Computed: true},
+			agentConfigKey:     {Type: schema.TypeString, Computed: true, Sensitive: true},
+			createTimeKey:      createTimeSchema(),
+			rotationKey:        rotationSchema(),
+			rotationTriggerKey: rotationTriggerSchema(),
 		},
 	}
+	maps.Copy(res.Schema, appAgentCredRotation.schema())
+	return res
 }
 
 // postAppAgentCredentialWithRetry issues the credential create request, retrying on
@@ -158,44 +195,23 @@ func resAppAgentCredCreate(ctx context.Context, data *schema.ResourceData, meta
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
-	req := CreateApplicationAgentCredentialRequest{
-		ApplicationAgentID: data.Get(appAgentIDKey).(string),
-		DisplayName:        data.Get(displayNameKey).(string),
-	}
-
-	if expRaw, ok := data.GetOk(expireTimeKey); ok {
-		exp, err := time.Parse(time.RFC3339, expRaw.(string))
+	expireTime := newExpireTime(data)
+	if expireTime != "" {
+		exp, err := time.Parse(time.RFC3339, expireTime)
 		if err != nil {
 			return append(d, buildPluginErrorWithAttrName(err.Error(), expireTimeKey))
 		}
-		req.ExpireTime = exp.Format(time.RFC3339)
-	}
-
-	if key, ok := data.GetOk(publicKeyPEMKey); ok {
-		req.PublicKeyPEM = strings.TrimSpace(key.(string))
-	} else if key, ok := data.GetOk(publicKeyJWKKey); ok {
-		req.PublicKeyJWK = strings.TrimSpace(key.(string))
+		expireTime = exp.Format(time.RFC3339)
 	}
 
-	resp, err := postAppAgentCredentialWithRetry(ctx, clientCtx.GetClient(), &req)
-	if IsNotFoundError(err) {
-		// Retries are exhausted and the referenced application agent is still not
-		// visible. Surface a hard error: the not-found warning HasFailed would emit
-		// leaves Terraform with an inconsistent (created-but-unset) result.
-		return append(d, diag.Errorf(
-			"application agent %q was not found after %d attempts; it may not have "+
-				"finished propagating on the backend",
-			req.ApplicationAgentID, credCreateMaxRetries+1)...)
-	}
+	id, agentConfig, err := createAppAgentCredential(clientCtx.GetClient(), data)(ctx, expireTime)
 	if HasFailed(&d, err) {
 		return d
 	}
-	data.SetId(resp.ID)
-	setData(&d, data, appAgentIDKey, resp.ApplicationAgentID)
+	data.SetId(id)
 
 	// Save agent_config from the create response — the API only returns
 	// the secret at creation time; the GET endpoint never includes it.
-	agentConfig := string(resp.AgentConfig)
 
 	readDiags := resAppAgentCredRead(ctx, data, meta)
 	d = append(d, readDiags...)
@@ -209,6 +225,107 @@ func resAppAgentCredCreate(ctx context.Context, data *schema.ResourceData, meta
 	return d
 }
 
+// createAppAgentCredential returns function creating credential of the configured application agent.
+func createAppAgentCredential(client *RestClient, data *schema.ResourceData) createCredentialFunc {
+	return func(ctx context.Context, expireTime string) (string, string, error) {
+		req := CreateApplicationAgentCredentialRequest{
+			ApplicationAgentID: data.Get(appAgentIDKey).(string),
+			DisplayName:        data.Get(displayNameKey).(string),
+			ExpireTime:         expireTime,
+		}
+		if key, ok := data.GetOk(publicKeyPEMKey); ok {
+			req.PublicKeyPEM = strings.TrimSpace(key.(string))
+		} else if key, ok := data.GetOk(publicKeyJWKKey); ok {
+			req.PublicKeyJWK = strings.TrimSpace(key.(string))
+		}
+		path, _ := data.Get(privateKeyFileKey).(string)
+		var privateKey jwk.Key
+		if path != "" {
+			var err error
+			if privateKey, req.PublicKeyJWK, err = generateAgentKeyPair(); err != nil {
+				return "", "", err
+			}
+		}
+
+		resp, err := postAppAgentCredentialWithRetry(ctx, client, &req)
+		if IsNotFoundError(err) {
+			// Retries are exhausted and the referenced application agent is still not
+			// visible. Surface a hard error: the not-found warning HasFailed would emit
+			// leaves Terraform with an inconsistent (created-but-unset) result.
+			return "", "", fmt.Errorf(
+				"application agent %q was not found after %d attempts; it may not have "+
+					"finished propagating on the backend",
+				req.ApplicationAgentID, credCreateMaxRetries+1)
+		}
+		if err != nil || privateKey == nil {
+			return resp.ID, string(resp.AgentConfig), err
+		}
+		agentConfig, err := agentConfigWithPrivateKey(resp.AgentConfig, privateKey, resp.Kid)
+		if err == nil {
+			err = os.WriteFile(path, []byte(agentConfig), 0o600)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to write %s: %w", privateKeyFileKey, err)
+			// Credential without the private key is useless, and its ID would not be stored anywhere
+			delErr := client.Delete(ctx, appAgentCredRotation.path+resp.ID)
+			if delErr != nil && !IsNotFoundError(delErr) {
+				err = errors.Join(err, fmt.Errorf("failed to delete credential %s: %w", resp.ID, delErr))
+			}
+			return "", "", err
+		}
+		return resp.ID, string(resp.AgentConfig), nil
+	}
+}
+
+// generateAgentKeyPair generates EC P-256 key pair and returns the private key
+// together with the public key in JWK format. Key ID is the JWK thumbprint.
+func generateAgentKeyPair() (jwk.Key, string, error) {
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	privateKey, err := jwk.FromRaw(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create JWK: %w", err)
+	}
+	thumbprint, err := privateKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+	_ = privateKey.Set(jwk.KeyIDKey, base64.RawURLEncoding.EncodeToString(thumbprint))
+	_ = privateKey.Set(jwk.KeyUsageKey, jwk.ForSignature)
+	_ = privateKey.Set(jwk.AlgorithmKey, jwa.ES256)
+
+	publicKey, err := privateKey.PublicKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get public key: %w", err)
+	}
+	publicJWK, err := json.Marshal(publicKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return privateKey, string(publicJWK), nil
+}
+
+// agentConfigWithPrivateKey adds private key into agent configuration returned by the API,
+// which knows only the public key. Key ID is replaced with the one assigned by the API, if any.
+func agentConfigWithPrivateKey(agentConfig json.RawMessage, privateKey jwk.Key, kid string) (string, error) {
+	config := map[string]any{}
+	if len(agentConfig) > 0 {
+		if err := json.Unmarshal(agentConfig, &config); err != nil {
+			return "", fmt.Errorf("failed to parse agent config: %w", err)
+		}
+	}
+	if kid != "" {
+		if err := privateKey.Set(jwk.KeyIDKey, kid); err != nil {
+			return "", err
+		}
+	}
+	config[privateKeyJWKField] = privateKey
+	result, err := json.Marshal(config)
+	return string(result), err
+}
+
 func resAppAgentCredRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
 	clientCtx := getClientContext(&d, meta)
@@ -240,10 +357,38 @@ func resAppAgentCredRead(ctx context.Context, data *schema.ResourceData, meta an
 	if !resp.ExpireTime.IsZero() {
 		setData(&d, data, expireTimeKey, resp.ExpireTime)
 	}
+	clientCtx.warnCredentialExpiry(&d, resp.ID, resp.Kid, resp.ExpireTime)
 
 	return d
 }
 
+func resAppAgentCredUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	var d diag.Diagnostics
+	clientCtx := getClientContext(&d, meta)
+	if clientCtx == nil {
+		return d
+	}
+	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	client := clientCtx.GetClient()
+	agentConfig := appAgentCredRotation.update(ctx, &d, client, data, createAppAgentCredential(client, data))
+	if d.HasError() {
+		return d
+	}
+	// The previous config stays in the state, as GET never returns it
+	if agentConfig == "" {
+		oldConfig, _ := data.GetChange(agentConfigKey)
+		agentConfig = oldConfig.(string)
+	}
+	readDiags := resAppAgentCredRead(ctx, data, meta)
+	d = append(d, readDiags...)
+	if agentConfig != "" && !readDiags.HasError() {
+		setData(&d, data, agentConfigKey, agentConfig)
+	}
+	return d
+}
+
 func resAppAgentCredDelete(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
 	clientCtx := getClientContext(&d, meta)
@@ -255,7 +400,11 @@ func resAppAgentCredDelete(ctx context.Context, data *schema.ResourceData, meta
 	}
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
-	err := clientCtx.GetClient().Delete(ctx, "/application-agent-credentials/"+data.Id())
-	HasFailed(&d, err)
+	appAgentCredRotation.delete(ctx, &d, clientCtx.GetClient(), data)
+	if path, _ := data.Get(privateKeyFileKey).(string); path != "" && !d.HasError() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			d = append(d, buildPluginErrorWithAttrName(err.Error(), privateKeyFileKey))
+		}
+	}
 	return d
 }
@@ -21,10 +21,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -444,3 +448,119 @@ func testAppAgentCredResourceDataExists(
 		return convertOmegaMatcherToError(MatchKeys(IgnoreExtras, keys), rs.Primary.Attributes)
 	}
 }
+
+var _ = Describe("Resource ApplicationAgentCredential with local key pair", func() {
+	const typeName = "indykite_application_agent_credential"
+	var (
+		mockServer *httptest.Server
+		server     tfprotov6.ProviderServer
+		schemas    *tfprotov6.GetProviderSchemaResponse
+		created    indykite.CreateApplicationAgentCredentialRequest
+		deleted    []string
+		ctx        = context.Background()
+	)
+
+	BeforeEach(func() {
+		DeferCleanup(indykite.SetCredCreateWaits(time.Millisecond, time.Millisecond, time.Millisecond))
+		deleted = nil
+		credential := indykite.ApplicationAgentCredentialResponse{
+			ID: appAgentCredID, Kid: "kid-1", CustomerID: customerID, AppSpaceID: appSpaceID,
+			ApplicationID: applicationID, ApplicationAgentID: appAgentID, CreateTime: time.Now(),
+		}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				Expect(json.NewDecoder(r.Body).Decode(&created)).To(Succeed())
+				resp := credential
+				resp.AgentConfig = json.RawMessage(`{"appAgentId":"` + appAgentID + `"}`)
+				_ = json.NewEncoder(w).Encode(resp)
+			case http.MethodGet:
+				_ = json.NewEncoder(w).Encode(credential)
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}))
+		server, schemas = configuredMuxServer(mockServer)
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	It("writes private key to local file and uploads only public key", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "agent.json")
+		typ := schemas.ResourceSchemas[typeName].ValueType()
+		config, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, map[string]any{
+			"app_agent_id": appAgentID, "private_key_file": path,
+		}))
+		Expect(err).To(Succeed())
+		prior, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+		Expect(err).To(Succeed())
+
+		planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: &prior, ProposedNewState: &config, Config: &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(planResp.Diagnostics).To(BeEmpty())
+		applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: &prior, PlannedState: planResp.PlannedState, Config: &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(applyResp.Diagnostics).To(BeEmpty())
+
+		Expect(created.PublicKeyJWK).To(ContainSubstring(`"kty":"EC"`))
+		Expect(created.PublicKeyJWK).NotTo(ContainSubstring(`"d"`))
+
+		info, err := os.Stat(path)
+		Expect(err).To(Succeed())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+		content, err := os.ReadFile(path)
+		Expect(err).To(Succeed())
+		Expect(string(content)).To(ContainSubstring(`"appAgentId":"` + appAgentID + `"`))
+		Expect(string(content)).To(ContainSubstring(`"kid":"kid-1"`))
+		Expect(string(content)).To(ContainSubstring(`"d":`))
+
+		state, err := applyResp.NewState.Unmarshal(typ)
+		Expect(err).To(Succeed())
+		Expect(indykite.StateValue(state)).To(HaveKeyWithValue("agent_config", Not(ContainSubstring(`"d":`))))
+
+		destroyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: applyResp.NewState, PlannedState: &prior, Config: &prior,
+		})
+		Expect(err).To(Succeed())
+		Expect(destroyResp.Diagnostics).To(BeEmpty())
+		Expect(deleted).To(ConsistOf("/configs/v1/application-agent-credentials/" + appAgentCredID))
+		Expect(path).NotTo(BeAnExistingFile())
+	})
+
+	It("deletes created credential when private key file cannot be written", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "missing-dir", "agent.json")
+		typ := schemas.ResourceSchemas[typeName].ValueType()
+		config, err := tfprotov6.NewDynamicValue(typ, protoValue(typ, map[string]any{
+			"app_agent_id": appAgentID, "private_key_file": path,
+		}))
+		Expect(err).To(Succeed())
+		prior, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+		Expect(err).To(Succeed())
+
+		planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName: typeName, PriorState: &prior, ProposedNewState: &config, Config: &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(planResp.Diagnostics).To(BeEmpty())
+		applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: typeName, PriorState: &prior, PlannedState: planResp.PlannedState, Config: &config,
+		})
+		Expect(err).To(Succeed())
+		Expect(applyResp.Diagnostics).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+			"Severity": Equal(tfprotov6.DiagnosticSeverityError),
+			"Summary":  ContainSubstring("failed to write private_key_file"),
+		}))))
+		Expect(deleted).To(ConsistOf("/configs/v1/application-agent-credentials/" + appAgentCredID))
+
+		state, err := applyResp.NewState.Unmarshal(typ)
+		Expect(err).To(Succeed())
+		Expect(state.IsNull()).To(BeTrue())
+	})
+})
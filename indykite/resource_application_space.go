@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -29,9 +30,7 @@ func resourceApplicationSpace() *schema.Resource {
 		ReadContext:   resAppSpaceReadContext,
 		UpdateContext: resAppSpaceUpdateContext,
 		DeleteContext: resAppSpaceDeleteContext,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      appSpaceImport.importer(),
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(20 * time.Minute),
 			Create:  schema.DefaultTimeout(20 * time.Minute),
@@ -46,6 +45,7 @@ func resourceApplicationSpace() *schema.Resource {
 			descriptionKey:        descriptionSchema(),
 			createTimeKey:         createTimeSchema(),
 			updateTimeKey:         updateTimeSchema(),
+			etagKey:               etagSchema(),
 			deletionProtectionKey: deletionProtectionSchema(),
 			ikgStatusKey:          ikgStatusSchema(),
 			regionKey:             regionSchema(),
@@ -84,6 +84,14 @@ func getDBConnection(data *schema.ResourceData) *DBConnection {
 	if url == "" {
 		return nil
 	}
+	if password == "" {
+		// Write-only password is available only in the configuration during apply
+		passwordWO, _ := data.GetRawConfigAt(
+			cty.GetAttrPath(dbConnectionKey).IndexInt(0).GetAttr(dbPasswordKey + writeOnlySuffix))
+		if passwordWO.Type() == cty.String && passwordWO.IsKnown() && !passwordWO.IsNull() {
+			password = passwordWO.AsString()
+		}
+	}
 
 	return &DBConnection{
 		URL:             url,
@@ -101,11 +109,9 @@ func setDBConnectionData(d *diag.Diagnostics, data *schema.ResourceData, dbConn
 		return
 	}
 
-	oldDBConn := getDBConnection(data)
-	oldPassword := ""
-	if oldDBConn != nil {
-		oldPassword = oldDBConn.Password
-	}
+	// API never returns the password, keep the one stored in the state, but never the write-only one
+	oldPassword, _ := data.Get(dbConnectionKey + ".0." + dbPasswordKey).(string)
+	oldVersion, _ := data.Get(dbConnectionKey + ".0." + dbPasswordKey + writeOnlyVersionSuffix).(int)
 	dbConnData := []map[string]any{
 		{
 			dbURLKey:             dbConn.URL,
@@ -116,6 +122,10 @@ func setDBConnectionData(d *diag.Diagnostics, data *schema.ResourceData, dbConn
 			dbAliasMappingKey:    dbConn.AliasMapping,
 		},
 	}
+	// Data source has no write-only password, so the version is never set there
+	if oldVersion != 0 {
+		dbConnData[0][dbPasswordKey+writeOnlyVersionSuffix] = oldVersion
+	}
 	setData(d, data, dbConnectionKey, dbConnData)
 }
 
@@ -171,6 +181,7 @@ func getStatus(ctx context.Context, clientCtx *ClientContext, data *schema.Resou
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, regionKey, resp.Region)
 	setData(&d, data, ikgSizeKey, resp.IKGSize)
 	setData(&d, data, replicaRegionKey, resp.ReplicaRegion)
@@ -234,7 +245,7 @@ func resAppSpaceReadContext(ctx context.Context, data *schema.ResourceData, meta
 
 	var resp ApplicationSpaceResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/projects", data)
+	path := buildReadPath(appSpaceImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -247,6 +258,7 @@ func resAppSpaceReadContext(ctx context.Context, data *schema.ResourceData, meta
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, regionKey, resp.Region)
 	setData(&d, data, ikgSizeKey, resp.IKGSize)
 	setData(&d, data, replicaRegionKey, resp.ReplicaRegion)
@@ -295,7 +307,7 @@ func resAppSpaceUpdateContext(ctx context.Context, data *schema.ResourceData, me
 	}
 
 	var resp ApplicationSpaceResponse
-	err := clientCtx.GetClient().Put(ctx, "/projects/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(ctx, "/projects/"+data.Id(), data.Get(etagKey).(string), req, &resp)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -314,7 +326,7 @@ func resAppSpaceDeleteContext(ctx context.Context, data *schema.ResourceData, me
 	if hasDeleteProtection(&d, data) {
 		return d
 	}
-	err := clientCtx.GetClient().Delete(ctx, "/projects/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/projects/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 
 	return d
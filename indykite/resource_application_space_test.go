@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -658,3 +659,126 @@ var _ = Describe("Resource ApplicationSpace Import by Name", func() {
 		})
 	})
 })
+
+var _ = Describe("Resource Application Space write-only DB password", func() {
+	const resourceName = "indykite_application_space.development"
+	var (
+		mockServer *httptest.Server
+		provider   *schema.Provider
+	)
+
+	BeforeEach(func() {
+		provider = indykite.Provider()
+	})
+
+	AfterEach(func() {
+		if mockServer != nil {
+			mockServer.Close()
+		}
+	})
+
+	It("Test write-only password is sent but not stored", func() {
+		tfConfigDef :=
+			`resource "indykite_application_space" "development" {
+				customer_id = "` + customerID + `"
+				name = "acme"
+				region = "us-east1"
+				deletion_protection = false
+				db_connection {
+					url = "postgresql://localhost:5432/test"
+					username = "testuser"
+					%s
+				}
+			}`
+
+		var sentPasswords []string
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut:
+				var req struct {
+					DBConnection *indykite.DBConnection `json:"db_connection"`
+				}
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				if req.DBConnection != nil {
+					sentPasswords = append(sentPasswords, req.DBConnection.Password)
+				}
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(indykite.ApplicationSpaceResponse{
+				ID:         appSpaceID,
+				CustomerID: customerID,
+				Name:       "acme",
+				Region:     "us-east1",
+				IKGStatus:  "APP_SPACE_IKG_STATUS_STATUS_ACTIVE",
+				DBConnection: &indykite.DBConnection{
+					URL:      "postgresql://localhost:5432/test",
+					Username: "testuser",
+				},
+			})
+		}))
+
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			ctx = indykite.WithClient(ctx, client)
+			return cfgFunc(ctx, data)
+		}
+
+		testSentPasswords := func(expected ...string) resource.TestCheckFunc {
+			return func(*terraform.State) error {
+				return convertOmegaMatcherToError(Equal(expected), sentPasswords)
+			}
+		}
+
+		resource.Test(GinkgoT(), resource.TestCase{
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
+			Steps: []resource.TestStep{
+				// Errors cases must be always first
+				{
+					Config: fmt.Sprintf(tfConfigDef, ""),
+					ExpectError: regexp.MustCompile(
+						`(?s)one of\s+` + "`" + `db_connection\.0\.password,db_connection\.0\.password_wo` +
+							"`" + `\s+must be\s+specified`),
+				},
+				{
+					Config: fmt.Sprintf(tfConfigDef, `password = "secret"
+						password_wo = "secret"`),
+					ExpectError: regexp.MustCompile(
+						`(?s)only one of\s+` + "`" + `db_connection\.0\.password,db_connection\.0\.password_wo` +
+							"`" + `\s+can be\s+specified`),
+				},
+				{
+					Config: fmt.Sprintf(tfConfigDef, `password = "secret"
+						password_wo_version = 1`),
+					ExpectError: regexp.MustCompile(
+						`(?s)"db_connection\.0\.password":\s+conflicts\s+with\s+db_connection\.0\.password_wo_version`),
+				},
+
+				// Success test cases
+				{
+					Config: fmt.Sprintf(tfConfigDef, `password_wo = "secret"
+						password_wo_version = 1`),
+					Check: resource.ComposeTestCheckFunc(
+						testAppSpaceResourceDataExists(resourceName),
+						testSentPasswords("secret"),
+						resource.TestCheckResourceAttr(resourceName, "db_connection.0.password_wo_version", "1"),
+						resource.TestCheckResourceAttr(resourceName, "db_connection.0.password", ""),
+						resource.TestCheckNoResourceAttr(resourceName, "db_connection.0.password_wo"),
+					),
+				},
+				{
+					// Only the version bump sends the new write-only value
+					Config: fmt.Sprintf(tfConfigDef, `password_wo = "rotated"
+						password_wo_version = 2`),
+					Check: resource.ComposeTestCheckFunc(
+						testSentPasswords("secret", "rotated"),
+						resource.TestCheckResourceAttr(resourceName, "db_connection.0.password_wo_version", "2"),
+						resource.TestCheckNoResourceAttr(resourceName, "db_connection.0.password_wo"),
+					),
+				},
+			},
+		})
+	})
+})
@@ -40,9 +40,8 @@ func resourceAuthorizationPolicy() *schema.Resource {
 		ReadContext:   resAuthorizationPolicyRead,
 		UpdateContext: resAuthorizationPolicyUpdate,
 		DeleteContext: resAuthorizationPolicyDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      authorizationPolicyImport.importer(),
+		CustomizeDiff: authzPolicyCustomizeDiff,
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -54,17 +53,26 @@ func resourceAuthorizationPolicy() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 
 			authzJSONConfigKey: {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{authzJSONConfigKey, authzPolicyKey},
 				DiffSuppressFunc: structure.SuppressJsonDiff,
-				ValidateFunc: validation.All(
-					validation.StringIsNotEmpty,
-					validation.StringIsJSON,
+				ValidateDiagFunc: validation.AllDiag(
+					validation.ToDiagFunc(validation.All(
+						validation.StringIsNotEmpty,
+						validation.StringIsJSON,
+					)),
+					validateAuthzPolicyJSON,
 				),
-				Description: "Configuration of Authorization Policy in JSON format, the same one exported by The Hub.",
+				Description: "Configuration of Authorization Policy in JSON format, the same one exported by The Hub. " +
+					"It is validated against the KBAC policy schema at plan time. " +
+					"When `policy` block is used, it contains the compiled JSON.",
 			},
+			authzPolicyKey: authzPolicyBlockSchema(),
 			authzStatusKey: {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -93,6 +101,10 @@ func resAuthorizationPolicyCreate(ctx context.Context, data *schema.ResourceData
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
+	policy, err := authzPolicyJSON(data)
+	if HasFailed(&d, err) {
+		return d
+	}
 	// Map status from Terraform format to API format
 	statusValue := data.Get(authzStatusKey).(string)
 	apiStatus := AuthorizationPolicyStatusToAPI[statusValue]
@@ -102,13 +114,13 @@ func resAuthorizationPolicyCreate(ctx context.Context, data *schema.ResourceData
 		Name:        data.Get(nameKey).(string),
 		DisplayName: stringValue(optionalString(data, displayNameKey)),
 		Description: stringValue(optionalString(data, descriptionKey)),
-		Policy:      data.Get(authzJSONConfigKey).(string),
+		Policy:      policy,
 		Status:      apiStatus,
 		Tags:        rawArrayToTypedArray[string](data.Get(authzTagsKey).([]any)),
 	}
 
 	var resp AuthorizationPolicyResponse
-	err := clientCtx.GetClient().Post(ctx, "/authorization-policies", req, &resp)
+	err = clientCtx.GetClient().Post(ctx, "/authorization-policies", req, &resp)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -128,7 +140,7 @@ func resAuthorizationPolicyRead(ctx context.Context, data *schema.ResourceData,
 
 	var resp AuthorizationPolicyResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/authorization-policies", data)
+	path := buildReadPath(authorizationPolicyImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -149,6 +161,12 @@ func resAuthorizationPolicyRead(ctx context.Context, data *schema.ResourceData,
 	setData(&d, data, displayNameKey, resp.DisplayName)
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, authzJSONConfigKey, resp.Policy)
+	// Policy block is refreshed only when used, policies which it cannot express keep the block as it is
+	if len(data.Get(authzPolicyKey).([]any)) > 0 {
+		if block, ok := decompilePolicyBlock(resp.Policy); ok {
+			setData(&d, data, authzPolicyKey, block)
+		}
+	}
 
 	// Map status from API format to Terraform format
 	terraformStatus := AuthorizationPolicyStatusFromAPI[resp.Status]
@@ -159,6 +177,7 @@ func resAuthorizationPolicyRead(ctx context.Context, data *schema.ResourceData,
 	setData(&d, data, authzTagsKey, resp.Tags)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 
 	return d
 }
@@ -172,7 +191,10 @@ func resAuthorizationPolicyUpdate(ctx context.Context, data *schema.ResourceData
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
-	policy := data.Get(authzJSONConfigKey).(string)
+	policy, err := authzPolicyJSON(data)
+	if HasFailed(&d, err) {
+		return d
+	}
 	statusValue := data.Get(authzStatusKey).(string)
 	apiStatus := AuthorizationPolicyStatusToAPI[statusValue]
 
@@ -188,7 +210,9 @@ func resAuthorizationPolicyUpdate(ctx context.Context, data *schema.ResourceData
 	}
 
 	var resp AuthorizationPolicyResponse
-	err := clientCtx.GetClient().Put(ctx, "/authorization-policies/"+data.Id(), req, &resp)
+	err = clientCtx.GetClient().PutIfMatch(
+		ctx, "/authorization-policies/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -205,7 +229,7 @@ func resAuthorizationPolicyDelete(ctx context.Context, data *schema.ResourceData
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/authorization-policies/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/authorization-policies/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
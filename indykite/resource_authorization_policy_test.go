@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -281,7 +282,7 @@ var _ = Describe("Resource Authorization Policy config", func() {
 						status = "active"
 					}
 					`,
-					ExpectError: regexp.MustCompile(`argument "json" is required`),
+					ExpectError: regexp.MustCompile("one of `json,policy` must be specified"),
 				},
 				{
 					Config: `resource "indykite_authorization_policy" "wonka" {
@@ -294,6 +295,21 @@ var _ = Describe("Resource Authorization Policy config", func() {
 					`,
 					ExpectError: regexp.MustCompile(`"json" contains an invalid JSON`),
 				},
+				{
+					Config: `resource "indykite_authorization_policy" "wonka" {
+						location = "` + customerID + `"
+						name = "wonka-authorization-policy-config"
+						status = "active"
+
+						json = jsonencode({
+							meta    = { policyVersion = "1.0-indykyte" }
+							subject = { type = "Person" }
+						})
+					}
+					`,
+					ExpectError: regexp.MustCompile(
+						`At JSON pointer "/meta/policyVersion": value must be '1.0-indykite'`),
+				},
 				{
 					Config: `resource "indykite_authorization_policy" "wonka" {
 						location = ""
@@ -355,6 +371,86 @@ var _ = Describe("Resource Authorization Policy config", func() {
 	})
 })
 
+var _ = Describe("Resource Authorization Policy etag", func() {
+	var (
+		mockServer *httptest.Server
+		meta       any
+		res        *schema.Resource
+		ifMatch    []string
+	)
+
+	BeforeEach(func() {
+		ifMatch = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifMatch = append(ifMatch, r.Method+" "+r.Header.Get("If-Match"))
+			switch {
+			case r.Method != http.MethodGet && r.Header.Get("If-Match") != "etag-v1":
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_ = json.NewEncoder(w).Encode(map[string]string{"message": "etag mismatch"})
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				_ = json.NewEncoder(w).Encode(indykite.AuthorizationPolicyResponse{
+					ID:         sampleID,
+					Name:       "wonka-authorization-policy-config",
+					AppSpaceID: appSpaceID,
+					Policy:     "{}",
+					Status:     "active",
+					Etag:       "etag-v2",
+				})
+			}
+		}))
+
+		provider := indykite.Provider()
+		cfgFunc := provider.ConfigureContextFunc
+		provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+			client := indykite.NewTestRestClient(mockServer.URL+"/configs/v1", mockServer.Client())
+			return cfgFunc(indykite.WithClient(ctx, client), data)
+		}
+		Expect(provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		meta = provider.Meta()
+		res = provider.ResourcesMap["indykite_authorization_policy"]
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	newData := func(etag string) *schema.ResourceData {
+		data := schema.TestResourceDataRaw(GinkgoT(), res.Schema, map[string]any{
+			"location": appSpaceID,
+			"name":     "wonka-authorization-policy-config",
+			"json":     "{}",
+			"status":   "active",
+		})
+		data.SetId(sampleID)
+		Expect(data.Set("etag", etag)).To(Succeed())
+		return data
+	}
+
+	It("sends etag with update and stores the new one", func() {
+		data := newData("etag-v1")
+		Expect(res.UpdateContext(context.Background(), data, meta)).To(BeEmpty())
+		Expect(data.Get("etag")).To(Equal("etag-v2"))
+		Expect(ifMatch).To(HaveExactElements("PUT etag-v1", "GET "))
+	})
+
+	It("reports concurrent modification on update", func() {
+		d := res.UpdateContext(context.Background(), newData("etag-v0"), meta)
+		Expect(d).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Severity": Equal(diag.Error),
+			"Summary":  Equal("Resource was modified outside of Terraform, please refresh"),
+			"Detail":   ContainSubstring("HTTP 412"),
+		})))
+	})
+
+	It("sends etag with delete", func() {
+		Expect(res.DeleteContext(context.Background(), newData("etag-v1"), meta)).To(BeEmpty())
+		Expect(res.DeleteContext(context.Background(), newData("etag-v0"), meta)).To(HaveLen(1))
+		Expect(ifMatch).To(HaveExactElements("DELETE etag-v1", "DELETE etag-v0"))
+	})
+})
+
 func testAuthorizationPolicyResourceDataExists(
 	n string,
 	expectedID string,
@@ -539,3 +635,190 @@ var _ = Describe("Resource Authorization Policy Import by Name", func() {
 		})
 	})
 })
+
+var _ = Describe("Resource Authorization Policy block", func() {
+	var (
+		server   *indykitetest.Server
+		provider *schema.Provider
+		res      *schema.Resource
+		ctx      = context.Background()
+	)
+
+	policyBlock := func(actions ...any) map[string]any {
+		return map[string]any{
+			"subject":  []any{map[string]any{"type": "Person"}},
+			"actions":  actions,
+			"resource": []any{map[string]any{"type": "Car"}},
+			"condition": []any{map[string]any{
+				"cypher": "MATCH (subject:Person)-[:OWNS]->(resource:Car)",
+				"filter": []any{map[string]any{
+					"attribute": "subject.property.username", "operator": "=", "value": "$username",
+				}},
+			}},
+		}
+	}
+
+	BeforeEach(func() {
+		server = indykitetest.NewServer()
+		DeferCleanup(server.Close)
+		provider = server.Provider()
+		Expect(provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		res = provider.ResourcesMap["indykite_authorization_policy"]
+	})
+
+	DescribeTable("validates configuration",
+		func(raw map[string]any, matcher OmegaMatcher) {
+			raw["name"] = "policy"
+			raw["location"] = appSpaceID
+			raw["status"] = "active"
+			Expect(res.Validate(terraform.NewResourceConfigRaw(raw))).To(matcher)
+		},
+		Entry("policy block", map[string]any{"policy": []any{policyBlock("CAN_DRIVE")}}, BeEmpty()),
+		Entry("both json and policy block", map[string]any{
+			"policy": []any{policyBlock("CAN_DRIVE")}, "json": `{}`,
+		}, ContainElement(HaveField("Detail", ContainSubstring("only one of `json,policy`")))),
+		Entry("neither json nor policy block", map[string]any{},
+			ContainElement(HaveField("Detail", ContainSubstring("one of `json,policy` must be specified")))),
+		Entry("invalid subject type", map[string]any{"policy": []any{func() map[string]any {
+			block := policyBlock("CAN_DRIVE")
+			block["subject"] = []any{map[string]any{"type": "Per son"}}
+			return block
+		}()}}, ContainElement(HaveField("Summary", ContainSubstring("must be a node label")))),
+		Entry("no actions", map[string]any{"policy": []any{policyBlock()}},
+			ContainElement(HaveField("Summary", ContainSubstring("Not enough list items")))),
+		Entry("invalid operator", map[string]any{"policy": []any{func() map[string]any {
+			block := policyBlock("CAN_DRIVE")
+			block["condition"].([]any)[0].(map[string]any)["filter"] = []any{map[string]any{
+				"attribute": "subject.external_id", "operator": "LIKE", "value": "x",
+			}}
+			return block
+		}()}}, ContainElement(HaveField("Summary",
+			ContainSubstring(`expected policy.0.condition.0.filter.0.operator to be one of`)))),
+	)
+
+	It("compiles policy block to JSON and reads it back", func() {
+		projectID, err := server.Seed(indykitetest.Projects, map[string]any{
+			"organization_id": server.OrganizationID(), "name": "project",
+		})
+		Expect(err).To(Succeed())
+		config := map[string]any{
+			"name": "policy", "location": projectID, "status": "active",
+			"policy": []any{policyBlock("CAN_DRIVE")},
+		}
+		diff, err := res.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), provider.Meta())
+		Expect(err).To(Succeed())
+		compiled := `{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Person"},` +
+			`"actions":["CAN_DRIVE"],"resource":{"type":"Car"},"condition":{` +
+			`"cypher":"MATCH (subject:Person)-[:OWNS]->(resource:Car)",` +
+			`"filter":[{"attribute":"subject.property.username","operator":"=","value":"$username"}]}}`
+		Expect(diff.Attributes["json"].New).To(MatchJSON(compiled))
+
+		data := schema.TestResourceDataRaw(GinkgoT(), res.Schema, config)
+		Expect(res.CreateContext(ctx, data, provider.Meta())).To(BeEmpty())
+		stored, _ := server.Object(indykitetest.AuthorizationPolicies, data.Id())
+		Expect(stored["policy"]).To(MatchJSON(compiled))
+		Expect(data.Get("json")).To(MatchJSON(compiled))
+
+		// Change made outside of Terraform shows up in the block
+		Expect(server.Modify(indykitetest.AuthorizationPolicies, data.Id(), map[string]any{
+			"policy": strings.Replace(compiled, `"CAN_DRIVE"`, `"CAN_DRIVE","CAN_SELL"`, 1),
+		})).To(Succeed())
+		Expect(res.ReadContext(ctx, data, provider.Meta())).To(BeEmpty())
+		Expect(data.Get("policy.0.actions")).To(Equal([]any{"CAN_DRIVE", "CAN_SELL"}))
+
+		diff, err = res.Diff(ctx, data.State(), terraform.NewResourceConfigRaw(config), provider.Meta())
+		Expect(err).To(Succeed())
+		Expect(diff.Attributes).To(HaveKeyWithValue("policy.0.actions.#", HaveField("New", "1")))
+		Expect(diff.Attributes["json"].New).To(MatchJSON(compiled))
+		Expect(diff.RequiresNew()).To(BeFalse())
+	})
+
+	It("keeps policy block when policy JSON cannot be expressed by it", func() {
+		data := res.TestResourceData()
+		Expect(data.Set("policy", []any{policyBlock("CAN_DRIVE")})).To(Succeed())
+		Expect(data.Set("json", `{}`)).To(Succeed())
+
+		id, err := server.Seed(indykitetest.Projects, map[string]any{
+			"organization_id": server.OrganizationID(), "name": "project",
+		})
+		Expect(err).To(Succeed())
+		policyID, err := server.Seed(indykitetest.AuthorizationPolicies, map[string]any{
+			"project_id": id, "name": "policy", "status": "ACTIVE",
+			"policy": `{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"}}`,
+		})
+		Expect(err).To(Succeed())
+		data.SetId(policyID)
+		Expect(res.ReadContext(ctx, data, provider.Meta())).To(BeEmpty())
+		Expect(data.Get("json")).To(ContainSubstring("1.0-ciq"))
+		Expect(data.Get("policy.0.actions")).To(Equal([]any{"CAN_DRIVE"}))
+	})
+})
+
+var _ = Describe("Resource Authorization Policy schema", func() {
+	validate := func(policyJSON string) diag.Diagnostics {
+		res := indykite.Provider().ResourcesMap["indykite_authorization_policy"]
+		return res.Validate(terraform.NewResourceConfigRaw(map[string]any{
+			"name": "policy", "location": appSpaceID, "status": "active", "json": policyJSON,
+		}))
+	}
+	schemaError := func(pointer, message string) OmegaMatcher {
+		return ContainElement(And(
+			HaveField("Severity", diag.Error),
+			HaveField("Summary", "Authorization policy does not match the KBAC policy schema"),
+			HaveField("Detail", fmt.Sprintf("At JSON pointer %q: %s", pointer, message)),
+		))
+	}
+
+	DescribeTable("validates policy JSON",
+		func(policyJSON string, matcher OmegaMatcher) {
+			Expect(validate(policyJSON)).To(matcher)
+		},
+		Entry("KBAC policy",
+			`{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Person"},"actions":["CAN_DRIVE"],`+
+				`"resource":{"type":"Car"},`+
+				`"condition":{"cypher":"MATCH (subject:Person)-[:OWNS]->(resource:Car)","filter":[`+
+				`{"app":"app1","attribute":"subject.property.username","operator":"=","value":"$username"}]}}`,
+			BeEmpty()),
+		Entry("CIQ policy with nested filters",
+			`{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},`+
+				`"condition":{"cypher":"MATCH (subject:Person)-[r1:HAS]->(ln:LicenseNumber)",`+
+				`"filter":[{"operator":"AND","operands":[`+
+				`{"attribute":"subject.external_id","operator":"=","value":"$id"},`+
+				`{"attribute":"$token.sub","operator":"=","value":"$sub"}]}]},`+
+				`"allowed_reads":{"nodes":["ln.*"],"relationships":["r1"]},`+
+				`"allowed_upserts":{"relationships":{"relationship_types":[`+
+				`{"type":"GRANTED","source_node_label":"Company","target_node_label":"PaymentMethod"}]}}}`,
+			BeEmpty()),
+		Entry("CIQ policy with upserts and deletes",
+			`{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},`+
+				`"allowed_upserts":{"nodes":{"existing_nodes":["vehicle"],"node_labels":["PaymentMethod"]},`+
+				`"relationships":{"existing_relationships":["r2"]}},`+
+				`"allowed_deletes":{"nodes":["ln"],"relationships":["r3"]}}`,
+			BeEmpty()),
+		Entry("missing resource",
+			`{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Person"},"actions":["A"]}`,
+			schemaError("", "missing property 'resource'")),
+		Entry("unknown version", `{"meta":{"policyVersion":"2.0"},"subject":{"type":"Person"}}`,
+			schemaError("/meta/policyVersion", "value must be '1.0-indykite'")),
+		Entry("invalid node type",
+			`{"meta":{"policyVersion":"1.0-indykite"},"subject":{"type":"Per son"},"actions":["A"],`+
+				`"resource":{"type":"Car"}}`,
+			schemaError("/subject/type", "'Per son' does not match pattern '^[A-Za-z][A-Za-z0-9_]*$'")),
+		Entry("invalid operator in nested filter",
+			`{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},"condition":{"cypher":"MATCH (subject)",`+
+				`"filter":[{"operator":"OR","operands":[`+
+				`{"attribute":"subject.external_id","operator":"LIKE","value":"x"}]}]}}`,
+			schemaError("/condition/filter/0/operands/0/operator", "value must be one of "+
+				"'=', '<>', '<', '<=', '>', '>=', 'IN', 'CONTAINS', 'STARTS WITH', 'ENDS WITH'")),
+		Entry("typo in nested property",
+			`{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},"allowed_reads":{"node":["ln"]}}`,
+			schemaError("/allowed_reads", "additional properties 'node' not allowed")),
+		Entry("top-level property unknown to the provider",
+			`{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},"allowed_traversals":{}}`,
+			BeEmpty()),
+		Entry("not JSON", "not valid json", And(
+			ContainElement(HaveField("Summary", ContainSubstring(`"json" contains an invalid JSON`))),
+			Not(ContainElement(HaveField("Summary", "Authorization policy does not match the KBAC policy schema"))),
+		)),
+	)
+})
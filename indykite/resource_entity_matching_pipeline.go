@@ -39,9 +39,7 @@ func resourceEntityMatchingPipeline() *schema.Resource {
 		ReadContext:   resEntityMatchingPipelineRead,
 		UpdateContext: resEntityMatchingPipelineUpdate,
 		DeleteContext: resEntityMatchingPipelineDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      entityMatchingPipelineImport.importer(),
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -54,6 +52,7 @@ func resourceEntityMatchingPipeline() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 
 			entityMatchingPipelineSourceNodeFilterKey: {
 				Type:        schema.TypeList,
@@ -137,7 +136,7 @@ func resEntityMatchingPipelineRead(ctx context.Context, data *schema.ResourceDat
 
 	var resp EntityMatchingPipelineResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/entity-matching-pipelines", data)
+	path := buildReadPath(entityMatchingPipelineImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -159,6 +158,7 @@ func resEntityMatchingPipelineRead(ctx context.Context, data *schema.ResourceDat
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 
 	if resp.NodeFilter != nil {
 		setData(&d, data, entityMatchingPipelineSourceNodeFilterKey, resp.NodeFilter.SourceNodeTypes)
@@ -196,7 +196,9 @@ func resEntityMatchingPipelineUpdate(ctx context.Context, data *schema.ResourceD
 	}
 
 	var resp EntityMatchingPipelineResponse
-	err := clientCtx.GetClient().Put(ctx, "/entity-matching-pipelines/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/entity-matching-pipelines/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -213,7 +215,7 @@ func resEntityMatchingPipelineDelete(ctx context.Context, data *schema.ResourceD
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/entity-matching-pipelines/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/entity-matching-pipelines/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
@@ -17,11 +17,21 @@ package indykite
 import (
 	"context"
 	"fmt"
+	"maps"
 	"regexp"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -104,11 +114,7 @@ const (
 |  |  |  |  | `
 )
 
-func resourceEventSink() *schema.Resource {
-	providerOneOf := []string{kafkaKey, azureEventGridKey, azureServiceBusKey, pubsubKey}
-
-	return &schema.Resource{
-		Description: `
+const eventSinkDescription = `
 		Event Sink configuration is used to configure outbound events.
 
 		There can be only one configuration per AppSpace (Project).
@@ -119,683 +125,701 @@ func resourceEventSink() *schema.Resource {
 		These external systems may require real-time synchronization or need to react to
 		changes occurring in the platform.
 
-		` + supportedFilters,
+		` + supportedFilters
 
-		CreateContext: resEventSinkCreate,
-		ReadContext:   resEventSinkRead,
-		UpdateContext: resEventSinkUpdate,
-		DeleteContext: resEventSinkDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+var (
+	_ resource.ResourceWithConfigure      = &eventSinkResource{}
+	_ resource.ResourceWithImportState    = &eventSinkResource{}
+	_ resource.ResourceWithValidateConfig = &eventSinkResource{}
+
+	eventSinkProviderTypes = []string{kafkaKey, azureEventGridKey, azureServiceBusKey, pubsubKey}
+)
+
+type (
+	// eventSinkResource is served by the framework provider, nested providers and routes
+	// keep the block syntax of the former SDK resource.
+	eventSinkResource struct {
+		clientCtx *ClientContext
+	}
+
+	eventSinkModel struct {
+		ID               types.String             `tfsdk:"id"`
+		Location         types.String             `tfsdk:"location"`
+		CustomerID       types.String             `tfsdk:"customer_id"`
+		AppSpaceID       types.String             `tfsdk:"app_space_id"`
+		Name             types.String             `tfsdk:"name"`
+		DisplayName      types.String             `tfsdk:"display_name"`
+		Description      types.String             `tfsdk:"description"`
+		CreateTime       types.String             `tfsdk:"create_time"`
+		UpdateTime       types.String             `tfsdk:"update_time"`
+		Etag             types.String             `tfsdk:"etag"`
+		Providers        []eventSinkProviderModel `tfsdk:"providers"`
+		Routes           []eventSinkRouteModel    `tfsdk:"routes"`
+		IncludeCDCEvents types.Bool               `tfsdk:"include_cdc_events"`
+		Timeouts         types.Object             `tfsdk:"timeouts"`
+	}
+
+	eventSinkProviderModel struct {
+		ProviderName    types.String           `tfsdk:"provider_name"`
+		Kafka           []kafkaSinkModel       `tfsdk:"kafka"`
+		AzureEventGrid  []azureEventGridModel  `tfsdk:"azure_event_grid"`
+		AzureServiceBus []azureServiceBusModel `tfsdk:"azure_service_bus"`
+		PubSub          []pubSubSinkModel      `tfsdk:"pubsub"`
+	}
+
+	kafkaSinkModel struct {
+		Brokers             []types.String `tfsdk:"brokers"`
+		Topic               types.String   `tfsdk:"topic"`
+		DisableTLS          types.Bool     `tfsdk:"disable_tls"`
+		TLSSkipVerify       types.Bool     `tfsdk:"tls_skip_verify"`
+		Username            types.String   `tfsdk:"username"`
+		Password            types.String   `tfsdk:"password"`
+		PasswordWO          types.String   `tfsdk:"password_wo"`
+		PasswordWOVersion   types.Int64    `tfsdk:"password_wo_version"`
+		ProviderDisplayName types.String   `tfsdk:"provider_display_name"`
+		LastError           types.String   `tfsdk:"last_error"`
+	}
+
+	azureEventGridModel struct {
+		TopicEndpoint       types.String `tfsdk:"topic_endpoint"`
+		AccessKey           types.String `tfsdk:"access_key"`
+		AccessKeyWO         types.String `tfsdk:"access_key_wo"`
+		AccessKeyWOVersion  types.Int64  `tfsdk:"access_key_wo_version"`
+		ProviderDisplayName types.String `tfsdk:"provider_display_name"`
+		LastError           types.String `tfsdk:"last_error"`
+	}
+
+	azureServiceBusModel struct {
+		ConnectionString          types.String `tfsdk:"connection_string"`
+		ConnectionStringWO        types.String `tfsdk:"connection_string_wo"`
+		ConnectionStringWOVersion types.Int64  `tfsdk:"connection_string_wo_version"`
+		QueueOrTopicName          types.String `tfsdk:"queue_or_topic_name"`
+		ProviderDisplayName       types.String `tfsdk:"provider_display_name"`
+		LastError                 types.String `tfsdk:"last_error"`
+	}
+
+	pubSubSinkModel struct {
+		ProjectID                types.String `tfsdk:"project_id"`
+		TopicName                types.String `tfsdk:"topic_name"`
+		CredentialsJSON          types.String `tfsdk:"credentials_json"`
+		CredentialsJSONWO        types.String `tfsdk:"credentials_json_wo"`
+		CredentialsJSONWOVersion types.Int64  `tfsdk:"credentials_json_wo_version"`
+		ProviderDisplayName      types.String `tfsdk:"provider_display_name"`
+		LastError                types.String `tfsdk:"last_error"`
+	}
+
+	eventSinkRouteModel struct {
+		ProviderID       types.String            `tfsdk:"provider_id"`
+		StopProcessing   types.Bool              `tfsdk:"stop_processing"`
+		KeysValuesFilter []keysValuesFilterModel `tfsdk:"keys_values_filter"`
+		RouteDisplayName types.String            `tfsdk:"route_display_name"`
+		RouteID          types.String            `tfsdk:"route_id"`
+	}
+
+	keysValuesFilterModel struct {
+		KeyValuePairs []keyValuePairModel `tfsdk:"key_value_pairs"`
+		EventType     types.String        `tfsdk:"event_type"`
+	}
+
+	keyValuePairModel struct {
+		Key   types.String `tfsdk:"key"`
+		Value types.String `tfsdk:"value"`
+	}
+)
+
+func newEventSinkResource() resource.Resource {
+	return &eventSinkResource{}
+}
 
-		Timeouts: defaultTimeouts(),
-		Schema: map[string]*schema.Schema{
-			locationKey:   locationSchema(),
-			customerIDKey: setComputed(customerIDSchema()),
-			appSpaceIDKey: setComputed(appSpaceIDSchema()),
-
-			nameKey:        nameSchema(),
-			displayNameKey: displayNameSchema(),
-			descriptionKey: descriptionSchema(),
-			createTimeKey:  createTimeSchema(),
-			updateTimeKey:  updateTimeSchema(),
-			providersKey: {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem:     &schema.Resource{Schema: providerSchema()},
+func (*eventSinkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event_sink"
+}
+
+func (*eventSinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: eventSinkDescription,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The ID of this resource.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			locationKey: schema.StringAttribute{
+				Required:    true,
+				Description: locationDescription,
+				Validators:  []validator.String{gidValidator()},
+			},
+			customerIDKey: schema.StringAttribute{
+				Computed:      true,
+				Description:   customerIDDescription,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			routesKey: {
-				Type:     schema.TypeList,
-				Elem:     &schema.Resource{Schema: routeSchema()},
-				Required: true,
+			appSpaceIDKey: schema.StringAttribute{
+				Computed:      true,
+				Description:   appSpaceIDDescription,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			includeCdcEventsKey: {
-				Type:     schema.TypeBool,
+			nameKey: schema.StringAttribute{
+				Required:    true,
+				Description: nameDescription,
+				Validators:  []validator.String{nameValidator()},
+			},
+			displayNameKey: schema.StringAttribute{
+				Optional:    true,
+				Description: displayNameSchema().Description,
+			},
+			descriptionKey: schema.StringAttribute{
+				Optional:    true,
+				Description: descriptionSchema().Description,
+				Validators:  []validator.String{stringvalidator.LengthBetween(0, 65000)},
+			},
+			createTimeKey: schema.StringAttribute{
+				Computed:      true,
+				Description:   createTimeSchema().Description,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			updateTimeKey: schema.StringAttribute{
+				Computed:    true,
+				Description: updateTimeSchema().Description,
+			},
+			etagKey: schema.StringAttribute{
+				Computed:    true,
+				Description: etagSchema().Description,
+			},
+			includeCdcEventsKey: schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 				Description: "When true, CDC (Change Data Capture) events will be emitted to this event sink. " +
 					"When false or unset, CDC events will not be emitted. " +
 					"Defaults to false for backward compatibility.",
 			},
 		},
-		CustomizeDiff: validateProviderOneOf(providerOneOf),
-	}
-}
-
-func providerSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		providerNameKey: {
-			Type:     schema.TypeString,
-			Required: true,
-			ValidateFunc: validation.All(
-				validation.StringLenBetween(2, 63),
-				validation.StringMatch(regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])$`),
-					"must start with a lowercase letter, followed by 0-62 characters "+
-						"(lowercase letters, digits, and hyphens in the middle)."),
-			),
-		},
-		kafkaKey: {
-			Type:        schema.TypeList,
-			MaxItems:    1,
-			Optional:    true,
-			Description: "KafkaSinkConfig",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					brokersKey: {
-						Type:        schema.TypeList,
-						Elem:        &schema.Schema{Type: schema.TypeString},
-						Required:    true,
-						Description: "Brokers specify Kafka destinations to connect to.",
-					},
-					topicKey: {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 249),
-							validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9._-]+$`),
-								"must contain only letters, numbers, underscores or hyphens"),
-						),
-					},
-					disableTLSKey: {
-						Type:        schema.TypeBool,
-						Optional:    true,
-						Description: "Disable TLS for communication. Highly NOT RECOMMENDED.",
+		Blocks: map[string]schema.Block{
+			providersKey: schema.ListNestedBlock{
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						providerNameKey: schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(2, 63),
+								stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])$`),
+									"must start with a lowercase letter, followed by 0-62 characters "+
+										"(lowercase letters, digits, and hyphens in the middle)."),
+							},
+						},
 					},
-					tlsSkipVerifyKey: {
-						Type:        schema.TypeBool,
-						Optional:    true,
-						Description: "Skip TLS certificate verification. NOT RECOMMENDED.",
-					},
-					usernameKey: {
-						Type:     schema.TypeString,
-						Required: true,
-					},
-					passwordKey: {
-						Type:      schema.TypeString,
-						Required:  true,
-						Sensitive: true,
-					},
-					providerDisplayKey: {
-						Type:     schema.TypeString,
-						Optional: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(2, 254),
-						),
-					},
-					lastErrorKey: {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Last error message from the Kafka sink",
+					Blocks: map[string]schema.Block{
+						kafkaKey:           kafkaSinkBlock(),
+						azureEventGridKey:  azureEventGridBlock(),
+						azureServiceBusKey: azureServiceBusBlock(),
+						pubsubKey:          pubSubSinkBlock(),
 					},
 				},
 			},
-		},
-		azureEventGridKey: {
-			Type:        schema.TypeList,
-			MaxItems:    1,
-			Optional:    true,
-			Description: "AzureEventGridSinkConfig",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					topicEndpointKey: {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 1024),
-						),
-					},
-					accessKey: {
-						Type:      schema.TypeString,
-						Required:  true,
-						Sensitive: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 1024),
-						),
+			routesKey: schema.ListNestedBlock{
+				Validators: []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						providerIDKey: schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(2, 63),
+								stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])$`),
+									"must contain only lowercase letters, numbers, or hyphens"),
+							},
+						},
+						stopProcessingKey: schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+						routeDisplayKey: schema.StringAttribute{
+							Optional:   true,
+							Validators: []validator.String{stringvalidator.LengthBetween(2, 254)},
+						},
+						routeIDKey: schema.StringAttribute{
+							Optional:   true,
+							Validators: []validator.String{stringvalidator.LengthBetween(2, 63)},
+						},
 					},
-					providerDisplayKey: {
-						Type:     schema.TypeString,
-						Optional: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(2, 254),
-						),
-					},
-					lastErrorKey: {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Last error message from the Azure Event Grid sink",
-					},
-				},
-			},
-		},
-		azureServiceBusKey: {
-			Type:        schema.TypeList,
-			MaxItems:    1,
-			Optional:    true,
-			Description: "AzureServiceBusSinkConfig",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					connectionStringKey: {
-						Type:      schema.TypeString,
-						Required:  true,
-						Sensitive: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 1024),
-						),
-					},
-					queueKey: {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 1024),
-						),
-					},
-					providerDisplayKey: {
-						Type:     schema.TypeString,
-						Optional: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(2, 254),
-						),
-					},
-					lastErrorKey: {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Last error message from the Azure Service Bus sink",
-					},
-				},
-			},
-		},
-		pubsubKey: {
-			Type:        schema.TypeList,
-			MaxItems:    1,
-			Optional:    true,
-			Description: "PubSubSinkConfig (Google Cloud Pub/Sub)",
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					projectIDKey: {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(6, 30),
-						),
-					},
-					topicNameKey: {
-						Type:     schema.TypeString,
-						Required: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(3, 255),
-						),
-					},
-					credentialsJSONKey: {
-						Type:      schema.TypeString,
-						Required:  true,
-						Sensitive: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(1, 10240),
-						),
-					},
-					providerDisplayKey: {
-						Type:     schema.TypeString,
-						Optional: true,
-						ValidateFunc: validation.All(
-							validation.StringLenBetween(2, 254),
-						),
-					},
-					lastErrorKey: {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "Last error message from the Pub/Sub sink",
+					Blocks: map[string]schema.Block{
+						keysValuesKey: keysValuesFilterBlock(),
 					},
 				},
 			},
+			timeoutsKey: resourceTimeoutsBlock(),
 		},
 	}
 }
 
-func routeSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		providerIDKey: {
-			Type:     schema.TypeString,
-			Required: true,
-			ValidateFunc: validation.All(
-				validation.StringLenBetween(2, 63),
-				validation.StringMatch(regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])$`),
-					"must contain only lowercase letters, numbers, or hyphens"),
-			),
-		},
-		stopProcessingKey: {
-			Type:     schema.TypeBool,
-			Optional: true,
-		},
-		keysValuesKey: {
-			Type:     schema.TypeList,
-			Elem:     &schema.Resource{Schema: keysValuesSchema()},
-			Optional: true,
-			MaxItems: 1,
-		},
-		routeDisplayKey: {
-			Type:     schema.TypeString,
-			Optional: true,
-			ValidateFunc: validation.All(
-				validation.StringLenBetween(2, 254),
-			),
-		},
-		routeIDKey: {
-			Type:     schema.TypeString,
-			Optional: true,
-			ValidateFunc: validation.All(
-				validation.StringLenBetween(2, 63),
-			),
-		},
+func providerDisplayNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:   true,
+		Validators: []validator.String{stringvalidator.LengthBetween(2, 254)},
 	}
 }
 
-func keysValuesSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		keyValuePairsKey: {
-			Type:        schema.TypeList,
-			Description: "List of key/value pairs for the ingest event types. ",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					keyKey: {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Key for the ingest eventType",
-					},
-					valueKey: {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Value for the ingest eventType",
-					},
-				},
+func lastErrorAttribute(sinkName string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:    true,
+		Description: "Last error message from the " + sinkName + " sink",
+	}
+}
+
+func kafkaSinkBlock() schema.ListNestedBlock {
+	attributes := map[string]schema.Attribute{
+		brokersKey: schema.ListAttribute{
+			ElementType: types.StringType,
+			Required:    true,
+			Description: "Brokers specify Kafka destinations to connect to.",
+		},
+		topicKey: schema.StringAttribute{
+			Required: true,
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 249),
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9._-]+$`),
+					"must contain only letters, numbers, underscores or hyphens"),
 			},
 		},
-		evTypeKey: {
-			Type:     schema.TypeString,
+		disableTLSKey: schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+			Description: "Disable TLS for communication. Highly NOT RECOMMENDED.",
+		},
+		tlsSkipVerifyKey: schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+			Description: "Skip TLS certificate verification. NOT RECOMMENDED.",
+		},
+		usernameKey: schema.StringAttribute{
 			Required: true,
-			ValidateFunc: validation.All(
-				validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9_*\\.]+$`),
-					"must contain only letters, numbers, underscores, asterisks and dots"),
-			),
 		},
+		providerDisplayKey: providerDisplayNameAttribute(),
+		lastErrorKey:       lastErrorAttribute("Kafka"),
 	}
-}
+	maps.Copy(attributes, secretAttributes(passwordKey))
 
-func resEventSinkCreate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
-	var d diag.Diagnostics
-	clientCtx := getClientContext(&d, meta)
-	if clientCtx == nil {
-		return d
+	return schema.ListNestedBlock{
+		Description: "KafkaSinkConfig",
+		Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
 	}
-	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutCreate))
-	defer cancel()
-
-	providers := data.Get(providersKey).([]any)
-	routes := data.Get(routesKey).([]any)
+}
 
-	req := CreateEventSinkRequest{
-		ProjectID:        data.Get(locationKey).(string),
-		Name:             data.Get(nameKey).(string),
-		DisplayName:      stringValue(optionalString(data, displayNameKey)),
-		Description:      stringValue(optionalString(data, descriptionKey)),
-		Providers:        buildProvidersMap(providers),
-		Routes:           buildRoutesList(routes),
-		IncludeCDCEvents: data.Get(includeCdcEventsKey).(bool),
+func azureEventGridBlock() schema.ListNestedBlock {
+	attributes := map[string]schema.Attribute{
+		topicEndpointKey: schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.LengthBetween(1, 1024)},
+		},
+		providerDisplayKey: providerDisplayNameAttribute(),
+		lastErrorKey:       lastErrorAttribute("Azure Event Grid"),
 	}
+	maps.Copy(attributes, secretAttributes(accessKey, stringvalidator.LengthBetween(1, 1024)))
 
-	var resp EventSinkResponse
-	err := clientCtx.GetClient().Post(ctx, "/event-sinks", req, &resp)
-	if HasFailed(&d, err) {
-		return d
+	return schema.ListNestedBlock{
+		Description: "AzureEventGridSinkConfig",
+		Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
 	}
-	data.SetId(resp.ID)
-
-	return resEventSinkRead(ctx, data, meta)
 }
 
-func resEventSinkRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
-	var d diag.Diagnostics
-	clientCtx := getClientContext(&d, meta)
-	if clientCtx == nil {
-		return d
-	}
-	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutRead))
-	defer cancel()
-
-	var resp EventSinkResponse
-	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/event-sinks", data)
-	err := clientCtx.GetClient().Get(ctx, path, &resp)
-	if readHasFailed(&d, err, data) {
-		return d
+func azureServiceBusBlock() schema.ListNestedBlock {
+	attributes := map[string]schema.Attribute{
+		queueKey: schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.LengthBetween(1, 1024)},
+		},
+		providerDisplayKey: providerDisplayNameAttribute(),
+		lastErrorKey:       lastErrorAttribute("Azure Service Bus"),
 	}
+	maps.Copy(attributes, secretAttributes(connectionStringKey, stringvalidator.LengthBetween(1, 1024)))
 
-	data.SetId(resp.ID)
-	setData(&d, data, customerIDKey, resp.CustomerID)
-	setData(&d, data, appSpaceIDKey, resp.AppSpaceID)
-
-	// Set location based on which is present
-	if resp.AppSpaceID != "" {
-		setData(&d, data, locationKey, resp.AppSpaceID)
-	} else if resp.CustomerID != "" {
-		setData(&d, data, locationKey, resp.CustomerID)
+	return schema.ListNestedBlock{
+		Description: "AzureServiceBusSinkConfig",
+		Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
 	}
-
-	setData(&d, data, nameKey, resp.Name)
-	setData(&d, data, displayNameKey, resp.DisplayName)
-	setData(&d, data, descriptionKey, resp.Description)
-	setData(&d, data, createTimeKey, resp.CreateTime)
-	setData(&d, data, updateTimeKey, resp.UpdateTime)
-
-	// Flatten providers and routes from Config
-	flattenEventSinkConfig(&d, data, resp.Config)
-
-	return d
 }
 
-func resEventSinkUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
-	var d diag.Diagnostics
-	clientCtx := getClientContext(&d, meta)
-	if clientCtx == nil {
-		return d
+func pubSubSinkBlock() schema.ListNestedBlock {
+	attributes := map[string]schema.Attribute{
+		projectIDKey: schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.LengthBetween(6, 30)},
+		},
+		topicNameKey: schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.LengthBetween(3, 255)},
+		},
+		providerDisplayKey: providerDisplayNameAttribute(),
+		lastErrorKey:       lastErrorAttribute("Pub/Sub"),
 	}
-	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutUpdate))
-	defer cancel()
-
-	providers := data.Get(providersKey).([]any)
-	routes := data.Get(routesKey).([]any)
+	maps.Copy(attributes, secretAttributes(credentialsJSONKey, stringvalidator.LengthBetween(1, 10240)))
 
-	req := UpdateEventSinkRequest{
-		DisplayName:      updateOptionalString(data, displayNameKey),
-		Description:      updateOptionalString(data, descriptionKey),
-		Providers:        buildProvidersMap(providers),
-		Routes:           buildRoutesList(routes),
-		IncludeCDCEvents: data.Get(includeCdcEventsKey).(bool),
+	return schema.ListNestedBlock{
+		Description: "PubSubSinkConfig (Google Cloud Pub/Sub)",
+		Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
 	}
+}
 
-	var resp EventSinkResponse
-	err := clientCtx.GetClient().Put(ctx, "/event-sinks/"+data.Id(), req, &resp)
-	if HasFailed(&d, err) {
-		return d
+func keysValuesFilterBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Validators: []validator.List{listvalidator.SizeAtMost(1)},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				evTypeKey: schema.StringAttribute{
+					Required: true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9_*\\.]+$`),
+							"must contain only letters, numbers, underscores, asterisks and dots"),
+					},
+				},
+			},
+			Blocks: map[string]schema.Block{
+				keyValuePairsKey: schema.ListNestedBlock{
+					Description: "List of key/value pairs for the ingest event types. ",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							keyKey: schema.StringAttribute{
+								Required:    true,
+								Description: "Key for the ingest eventType",
+							},
+							valueKey: schema.StringAttribute{
+								Required:    true,
+								Description: "Value for the ingest eventType",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
-
-	return resEventSinkRead(ctx, data, meta)
 }
 
-func resEventSinkDelete(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
-	var d diag.Diagnostics
-	clientCtx := getClientContext(&d, meta)
-	if clientCtx == nil {
-		return d
+func (r *eventSinkResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider is not configured yet during validation
+	if req.ProviderData == nil {
+		return
 	}
-	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
-	defer cancel()
-
-	err := clientCtx.GetClient().Delete(ctx, "/event-sinks/"+data.Id())
-	HasFailed(&d, err)
-	return d
+	r.clientCtx = frameworkClientContext(&resp.Diagnostics, req.ProviderData)
 }
 
-// buildProvidersMap builds the providers map from Terraform schema data.
-func buildProvidersMap(providers []any) map[string]any {
-	providersMap := make(map[string]any, len(providers))
-	for _, provider := range providers {
-		item, ok := provider.(map[string]any)
-		if !ok {
+// ValidateConfig checks every provider has exactly one sink configuration.
+func (*eventSinkResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var providers types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(providersKey), &providers)...)
+	if resp.Diagnostics.HasError() || providers.IsNull() || providers.IsUnknown() {
+		return
+	}
+	for i, elem := range providers.Elements() {
+		provider, ok := elem.(types.Object)
+		if !ok || provider.IsUnknown() {
 			continue
 		}
-		key := item[providerNameKey].(string)
-		providerConfig := buildProviderConfig(item)
-		if providerConfig != nil {
-			providersMap[key] = providerConfig
+		count, unknown := 0, false
+		for _, key := range eventSinkProviderTypes {
+			sink, _ := provider.Attributes()[key].(types.List)
+			switch {
+			case sink.IsUnknown():
+				unknown = true
+			case len(sink.Elements()) > 0:
+				count++
+			}
+		}
+		if !unknown && count != 1 {
+			resp.Diagnostics.AddAttributeError(path.Root(providersKey).AtListIndex(i),
+				"Invalid provider", fmt.Sprintf("exactly one of providers must be specified in providers[%d]", i))
 		}
 	}
-	return providersMap
 }
 
-// buildProviderConfig builds a single provider configuration.
-func buildProviderConfig(item map[string]any) map[string]any {
-	if kafkaList, ok := item[kafkaKey].([]any); ok && len(kafkaList) > 0 {
-		return buildKafkaProviderMap(kafkaList[0].(map[string]any))
-	}
-	if gridList, ok := item[azureEventGridKey].([]any); ok && len(gridList) > 0 {
-		return buildAzureEventGridMap(gridList[0].(map[string]any))
-	}
-	if busList, ok := item[azureServiceBusKey].([]any); ok && len(busList) > 0 {
-		return buildAzureServiceBusMap(busList[0].(map[string]any))
+func (r *eventSinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config eventSinkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if pubsubList, ok := item[pubsubKey].([]any); ok && len(pubsubList) > 0 {
-		return buildPubSubProviderMap(pubsubList[0].(map[string]any))
-	}
-	return nil
-}
+	ctx, cancel := context.WithTimeout(ctx, operationTimeout(plan.Timeouts, timeoutCreateKey))
+	defer cancel()
 
-// buildKafkaProviderMap builds Kafka provider configuration map.
-func buildKafkaProviderMap(kafkaData map[string]any) map[string]any {
-	return map[string]any{
-		"kafka": map[string]any{
-			"brokers":         kafkaData[brokersKey],
-			"topic":           kafkaData[topicKey],
-			"username":        kafkaData[usernameKey],
-			"password":        kafkaData[passwordKey],
-			"disable_tls":     kafkaData[disableTLSKey],
-			"tls_skip_verify": kafkaData[tlsSkipVerifyKey],
-			"display_name":    kafkaData[providerDisplayKey],
-		},
+	body := CreateEventSinkRequest{
+		ProjectID:        plan.Location.ValueString(),
+		Name:             plan.Name.ValueString(),
+		DisplayName:      plan.DisplayName.ValueString(),
+		Description:      plan.Description.ValueString(),
+		Providers:        buildProvidersMap(withWriteOnlySecrets(plan.Providers, config.Providers)),
+		Routes:           buildRoutesList(plan.Routes),
+		IncludeCDCEvents: plan.IncludeCDCEvents.ValueBool(),
 	}
-}
 
-// buildAzureEventGridMap builds Azure Event Grid configuration map.
-func buildAzureEventGridMap(gridData map[string]any) map[string]any {
-	return map[string]any{
-		"azure_event_grid": map[string]any{
-			"topic_endpoint": gridData[topicEndpointKey],
-			"access_key":     gridData[accessKey],
-			"display_name":   gridData[providerDisplayKey],
-		},
+	var created EventSinkResponse
+	err := r.clientCtx.GetClient().Post(ctx, "/event-sinks", body, &created)
+	if frameworkHasFailed(&resp.Diagnostics, err) {
+		return
 	}
-}
 
-// buildAzureServiceBusMap builds Azure Service Bus configuration map.
-func buildAzureServiceBusMap(busData map[string]any) map[string]any {
-	return map[string]any{
-		"azure_service_bus": map[string]any{
-			"connection_string":   busData[connectionStringKey],
-			"queue_or_topic_name": busData[queueKey],
-			"display_name":        busData[providerDisplayKey],
-		},
+	state := readEventSink(ctx, &resp.Diagnostics, r.clientCtx, created.ID, &plan)
+	if state == nil {
+		resp.Diagnostics.AddError("Cannot read created event sink", "Event sink "+created.ID+" was not found")
+		return
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-// buildPubSubProviderMap builds Google Cloud Pub/Sub configuration map.
-func buildPubSubProviderMap(pubsubData map[string]any) map[string]any {
-	return map[string]any{
-		"pubsub": map[string]any{
-			"project_id":       pubsubData[projectIDKey],
-			"topic_name":       pubsubData[topicNameKey],
-			"credentials_json": pubsubData[credentialsJSONKey],
-			"display_name":     pubsubData[providerDisplayKey],
-		},
+func (r *eventSinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state eventSinkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-}
+	ctx, cancel := context.WithTimeout(ctx, operationTimeout(state.Timeouts, timeoutReadKey))
+	defer cancel()
 
-// buildRoutesList builds the routes list from Terraform schema data.
-func buildRoutesList(routes []any) []any {
-	routesList := make([]any, len(routes))
-	for i, route := range routes {
-		item, ok := route.(map[string]any)
-		if !ok {
-			continue
+	newState := readEventSink(ctx, &resp.Diagnostics, r.clientCtx, state.ID.ValueString(), &state)
+	if newState == nil {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
 		}
-		routesList[i] = buildRouteMap(item)
+		return
 	}
-	return routesList
+	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
 }
 
-// buildRouteMap builds a single route map.
-func buildRouteMap(item map[string]any) map[string]any {
-	routeMap := map[string]any{
-		"provider_id":     item[providerIDKey],
-		"stop_processing": item[stopProcessingKey],
-		"display_name":    item[routeDisplayKey],
-		"id":              item[routeIDKey],
+func (r *eventSinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, config, state eventSinkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, operationTimeout(plan.Timeouts, timeoutUpdateKey))
+	defer cancel()
 
-	if kvList, ok := item[keysValuesKey].([]any); ok && len(kvList) > 0 {
-		routeMap["event_type_key_values_filter"] = buildKeysValuesMap(kvList[0].(map[string]any))
+	body := UpdateEventSinkRequest{
+		DisplayName:      changedString(plan.DisplayName, state.DisplayName),
+		Description:      changedString(plan.Description, state.Description),
+		Providers:        buildProvidersMap(withWriteOnlySecrets(plan.Providers, config.Providers)),
+		Routes:           buildRoutesList(plan.Routes),
+		IncludeCDCEvents: plan.IncludeCDCEvents.ValueBool(),
 	}
 
-	return routeMap
-}
-
-// buildKeysValuesMap builds the keysValues map for a route.
-func buildKeysValuesMap(kvData map[string]any) map[string]any {
-	var pairs []any
-	if pairsList, ok := kvData[keyValuePairsKey].([]any); ok {
-		pairs = make([]any, 0, len(pairsList))
-		for _, pair := range pairsList {
-			if pairData, ok := pair.(map[string]any); ok {
-				pairs = append(pairs, map[string]any{
-					"key":   pairData[keyKey],
-					"value": pairData[valueKey],
-				})
-			}
-		}
+	var updated EventSinkResponse
+	err := r.clientCtx.GetClient().PutIfMatch(ctx,
+		"/event-sinks/"+state.ID.ValueString(), state.Etag.ValueString(), body, &updated)
+	if frameworkHasFailed(&resp.Diagnostics, err) {
+		return
 	}
-	return map[string]any{
-		"key_value_pairs": pairs,
-		"event_type":      kvData[evTypeKey],
-	}
-}
 
-// buildKafkaConfig builds Kafka configuration preserving sensitive password from state.
-func buildKafkaConfig(kafkaData map[string]any, data *schema.ResourceData) map[string]any {
-	// Preserve sensitive password from state
-	var oldPassword any
-	if val, ok := data.Get(providersKey).([]any); ok && len(val) > 0 {
-		for _, y := range val {
-			if kafkaList, ok := y.(map[string]any)[kafkaKey].([]any); ok && len(kafkaList) > 0 {
-				kafka := kafkaList[0].(map[string]any)
-				oldPassword = kafka[passwordKey]
-			}
-		}
+	newState := readEventSink(ctx, &resp.Diagnostics, r.clientCtx, state.ID.ValueString(), &plan)
+	if newState == nil {
+		resp.Diagnostics.AddError("Cannot read updated event sink",
+			"Event sink "+state.ID.ValueString()+" was not found")
+		return
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+}
 
-	// Helper to get value from either snake_case or camelCase
-	getValue := func(snakeCase, camelCase string) any {
-		if val, ok := kafkaData[snakeCase]; ok {
-			return val
-		}
-		return kafkaData[camelCase]
+func (r *eventSinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state eventSinkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, operationTimeout(state.Timeouts, timeoutDeleteKey))
+	defer cancel()
 
-	return map[string]any{
-		brokersKey:         getValue("brokers", "brokers"),
-		topicKey:           getValue("topic", "topic"),
-		usernameKey:        getValue("username", "username"),
-		passwordKey:        oldPassword,
-		disableTLSKey:      getValue("disable_tls", "disableTls"),
-		tlsSkipVerifyKey:   getValue("tls_skip_verify", "tlsSkipVerify"),
-		providerDisplayKey: getValue("display_name", "displayName"),
-	}
+	err := r.clientCtx.GetClient().DeleteIfMatch(ctx, "/event-sinks/"+state.ID.ValueString(), state.Etag.ValueString())
+	frameworkHasFailed(&resp.Diagnostics, err)
 }
 
-// flattenEventSinkConfig converts Config map to Terraform schema data.
-func buildAzureEventGridConfig(gridData map[string]any, data *schema.ResourceData) map[string]any {
-	// Preserve sensitive access key from state
-	var oldAccessKey any
-	if val, ok := data.Get(providersKey).([]any); ok && len(val) > 0 {
-		for _, y := range val {
-			if gridList, ok := y.(map[string]any)[azureEventGridKey].([]any); ok && len(gridList) > 0 {
-				grid := gridList[0].(map[string]any)
-				oldAccessKey = grid[accessKey]
-			}
-		}
-	}
-
-	// Helper to get value from either snake_case or camelCase
-	getValue := func(snakeCase, camelCase string) any {
-		if val, ok := gridData[snakeCase]; ok {
-			return val
-		}
-		return gridData[camelCase]
-	}
+// ImportState accepts the same import ID formats as SDK resources, see importSpec.stateContext.
+func (r *eventSinkResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	id, err := eventSinkImport.resolveImportID(ctx, r.clientCtx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import event sink", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
 
-	return map[string]any{
-		topicEndpointKey:   getValue("topic_endpoint", "topicEndpoint"),
-		accessKey:          oldAccessKey,
-		providerDisplayKey: getValue("display_name", "displayName"),
+// readEventSink fetches the event sink by ID, or by "name?location=gid" used by the data source.
+// Prior model provides sensitive values, which are never returned by the API, and the order of providers.
+// Returns nil when the event sink does not exist or request failed.
+func readEventSink(
+	ctx context.Context,
+	d *fwdiag.Diagnostics,
+	clientCtx *ClientContext,
+	id string,
+	prior *eventSinkModel,
+) *eventSinkModel {
+	var resp EventSinkResponse
+	err := clientCtx.GetClient().Get(ctx, eventSinkImport.readPath(id), &resp)
+	if frameworkHasFailed(d, err) {
+		return nil
 	}
+	return flattenEventSink(&resp, prior)
 }
 
-func buildAzureServiceBusConfig(busData map[string]any, data *schema.ResourceData) map[string]any {
-	// Preserve sensitive connection string from state
-	var oldConnection any
-	if val, ok := data.Get(providersKey).([]any); ok && len(val) > 0 {
-		for _, y := range val {
-			if busList, ok := y.(map[string]any)[azureServiceBusKey].([]any); ok && len(busList) > 0 {
-				bus := busList[0].(map[string]any)
-				oldConnection = bus[connectionStringKey]
-			}
-		}
-	}
+// flattenEventSink converts API response into the model.
+func flattenEventSink(resp *EventSinkResponse, prior *eventSinkModel) *eventSinkModel {
+	model := &eventSinkModel{
+		ID:          types.StringValue(resp.ID),
+		CustomerID:  types.StringValue(resp.CustomerID),
+		AppSpaceID:  types.StringValue(resp.AppSpaceID),
+		Location:    types.StringValue(resp.AppSpaceID),
+		Name:        types.StringValue(resp.Name),
+		DisplayName: displayNameOrNull(resp.DisplayName, resp.Name, prior.DisplayName),
+		Description: stringOrNull(resp.Description),
+		CreateTime:  timeOrNull(resp.CreateTime),
+		UpdateTime:  timeOrNull(resp.UpdateTime),
+		Etag:        types.StringValue(resp.Etag),
+		Timeouts:    prior.Timeouts,
+	}
+	if resp.AppSpaceID == "" {
+		model.Location = types.StringValue(resp.CustomerID)
+	}
+
+	providersMap, _ := resp.Config["providers"].(map[string]any)
+	model.Providers = flattenEventSinkProviders(providersMap, prior.Providers)
+	routesList, _ := resp.Config["routes"].([]any)
+	model.Routes = flattenEventSinkRoutes(routesList)
+	includeCDC, _ := getEither(resp.Config, "include_cdc_events", "includeCdcEvents").(bool)
+	model.IncludeCDCEvents = types.BoolValue(includeCDC)
+	return model
+}
 
-	// Helper to get value from either snake_case or camelCase
-	getValue := func(snakeCase, camelCase string) any {
-		if val, ok := busData[snakeCase]; ok {
-			return val
+// buildProvidersMap builds the providers map of the request from the model.
+func buildProvidersMap(providers []eventSinkProviderModel) map[string]any {
+	providersMap := make(map[string]any, len(providers))
+	for _, provider := range providers {
+		var providerConfig map[string]any
+		switch {
+		case len(provider.Kafka) > 0:
+			kafka := provider.Kafka[0]
+			providerConfig = map[string]any{"kafka": map[string]any{
+				"brokers":         stringValues(kafka.Brokers),
+				"topic":           kafka.Topic.ValueString(),
+				"username":        kafka.Username.ValueString(),
+				"password":        secretValue(kafka.Password, kafka.PasswordWO),
+				"disable_tls":     kafka.DisableTLS.ValueBool(),
+				"tls_skip_verify": kafka.TLSSkipVerify.ValueBool(),
+				"display_name":    kafka.ProviderDisplayName.ValueString(),
+			}}
+		case len(provider.AzureEventGrid) > 0:
+			grid := provider.AzureEventGrid[0]
+			providerConfig = map[string]any{"azure_event_grid": map[string]any{
+				"topic_endpoint": grid.TopicEndpoint.ValueString(),
+				"access_key":     secretValue(grid.AccessKey, grid.AccessKeyWO),
+				"display_name":   grid.ProviderDisplayName.ValueString(),
+			}}
+		case len(provider.AzureServiceBus) > 0:
+			bus := provider.AzureServiceBus[0]
+			providerConfig = map[string]any{"azure_service_bus": map[string]any{
+				"connection_string":   secretValue(bus.ConnectionString, bus.ConnectionStringWO),
+				"queue_or_topic_name": bus.QueueOrTopicName.ValueString(),
+				"display_name":        bus.ProviderDisplayName.ValueString(),
+			}}
+		case len(provider.PubSub) > 0:
+			pubsub := provider.PubSub[0]
+			providerConfig = map[string]any{"pubsub": map[string]any{
+				"project_id":       pubsub.ProjectID.ValueString(),
+				"topic_name":       pubsub.TopicName.ValueString(),
+				"credentials_json": secretValue(pubsub.CredentialsJSON, pubsub.CredentialsJSONWO),
+				"display_name":     pubsub.ProviderDisplayName.ValueString(),
+			}}
+		default:
+			continue
 		}
-		return busData[camelCase]
-	}
-
-	return map[string]any{
-		connectionStringKey: oldConnection,
-		queueKey:            getValue("queue_or_topic_name", "queueOrTopicName"),
-		providerDisplayKey:  getValue("display_name", "displayName"),
+		providersMap[provider.ProviderName.ValueString()] = providerConfig
 	}
+	return providersMap
 }
 
-func buildPubSubConfig(pubsubData map[string]any, data *schema.ResourceData) map[string]any {
-	// Preserve sensitive credentials from state
-	var oldCredentials any
-	if val, ok := data.Get(providersKey).([]any); ok && len(val) > 0 {
-		for _, y := range val {
-			if pubsubList, ok := y.(map[string]any)[pubsubKey].([]any); ok && len(pubsubList) > 0 {
-				ps := pubsubList[0].(map[string]any)
-				oldCredentials = ps[credentialsJSONKey]
-			}
+// withWriteOnlySecrets returns copy of planned providers with write-only secrets taken from the configuration,
+// because write-only values are always null in the plan. The API requires secrets with every update,
+// so they are sent even when only other fields changed.
+func withWriteOnlySecrets(planned, configured []eventSinkProviderModel) []eventSinkProviderModel {
+	providers := slices.Clone(planned)
+	for i := range providers {
+		if i >= len(configured) {
+			break
 		}
-	}
-
-	// Helper to get value from either snake_case or camelCase
-	getValue := func(snakeCase, camelCase string) any {
-		if val, ok := pubsubData[snakeCase]; ok {
-			return val
+		provider, config := &providers[i], &configured[i]
+		if len(provider.Kafka) > 0 && len(config.Kafka) > 0 {
+			provider.Kafka = slices.Clone(provider.Kafka)
+			provider.Kafka[0].PasswordWO = config.Kafka[0].PasswordWO
+		}
+		if len(provider.AzureEventGrid) > 0 && len(config.AzureEventGrid) > 0 {
+			provider.AzureEventGrid = slices.Clone(provider.AzureEventGrid)
+			provider.AzureEventGrid[0].AccessKeyWO = config.AzureEventGrid[0].AccessKeyWO
+		}
+		if len(provider.AzureServiceBus) > 0 && len(config.AzureServiceBus) > 0 {
+			provider.AzureServiceBus = slices.Clone(provider.AzureServiceBus)
+			provider.AzureServiceBus[0].ConnectionStringWO = config.AzureServiceBus[0].ConnectionStringWO
+		}
+		if len(provider.PubSub) > 0 && len(config.PubSub) > 0 {
+			provider.PubSub = slices.Clone(provider.PubSub)
+			provider.PubSub[0].CredentialsJSONWO = config.PubSub[0].CredentialsJSONWO
 		}
-		return pubsubData[camelCase]
-	}
-
-	return map[string]any{
-		projectIDKey:       getValue("project_id", "projectId"),
-		topicNameKey:       getValue("topic_name", "topicName"),
-		credentialsJSONKey: oldCredentials,
-		providerDisplayKey: getValue("display_name", "displayName"),
 	}
+	return providers
 }
 
-func flattenEventSinkConfig(d *diag.Diagnostics, data *schema.ResourceData, config map[string]any) {
-	providersMap, _ := config["providers"].(map[string]any)
-	setData(d, data, providersKey, flattenEventSinkProviders(data, providersMap))
-
-	routesList, _ := config["routes"].([]any)
-	setData(d, data, routesKey, flattenEventSinkRoutes(routesList))
-
-	if v, ok := config["include_cdc_events"]; ok {
-		setData(d, data, includeCdcEventsKey, v)
-	} else if v, ok := config["includeCdcEvents"]; ok {
-		setData(d, data, includeCdcEventsKey, v)
+// buildRoutesList builds the routes list of the request from the model.
+func buildRoutesList(routes []eventSinkRouteModel) []any {
+	routesList := make([]any, len(routes))
+	for i, route := range routes {
+		routeMap := map[string]any{
+			"provider_id":     route.ProviderID.ValueString(),
+			"stop_processing": route.StopProcessing.ValueBool(),
+			"display_name":    route.RouteDisplayName.ValueString(),
+			"id":              route.RouteID.ValueString(),
+		}
+		if len(route.KeysValuesFilter) > 0 {
+			filter := route.KeysValuesFilter[0]
+			pairs := make([]any, len(filter.KeyValuePairs))
+			for j, pair := range filter.KeyValuePairs {
+				pairs[j] = map[string]any{
+					"key":   pair.Key.ValueString(),
+					"value": pair.Value.ValueString(),
+				}
+			}
+			routeMap["event_type_key_values_filter"] = map[string]any{
+				"key_value_pairs": pairs,
+				"event_type":      filter.EventType.ValueString(),
+			}
+		}
+		routesList[i] = routeMap
 	}
+	return routesList
 }
 
 // pickMap returns the first value, as a map, found under any of the given keys.
@@ -817,109 +841,146 @@ func getEither(m map[string]any, snakeCase, camelCase string) any {
 	return m[camelCase]
 }
 
-// flattenEventSinkProviders converts the API providers map into Terraform schema blocks.
-func flattenEventSinkProviders(data *schema.ResourceData, providersMap map[string]any) []map[string]any {
-	var results []map[string]any
-	for key, p := range providersMap {
-		providerData, _ := p.(map[string]any)
-
-		if kafkaData, ok := pickMap(providerData, "kafka"); ok {
-			results = append(results, map[string]any{
-				providerNameKey: key,
-				kafkaKey:        []any{buildKafkaConfig(kafkaData, data)},
-			})
+// flattenEventSinkProviders converts the API providers map into the model.
+// The API returns providers as a map, so known providers keep the order of the prior model
+// and new ones follow sorted by name. Sensitive values are taken from the prior provider of the same name.
+func flattenEventSinkProviders(providersMap map[string]any, prior []eventSinkProviderModel) []eventSinkProviderModel {
+	priorByName := make(map[string]*eventSinkProviderModel, len(prior))
+	var names []string
+	for i := range prior {
+		name := prior[i].ProviderName.ValueString()
+		if _, ok := providersMap[name]; ok && priorByName[name] == nil {
+			names = append(names, name)
+		}
+		priorByName[name] = &prior[i]
+	}
+	newNames := make([]string, 0, len(providersMap))
+	for name := range providersMap {
+		if priorByName[name] == nil {
+			newNames = append(newNames, name)
+		}
+	}
+	slices.Sort(newNames)
+	names = append(names, newNames...)
+
+	results := make([]eventSinkProviderModel, 0, len(names))
+	for _, name := range names {
+		providerData, _ := providersMap[name].(map[string]any)
+		priorProvider := priorByName[name]
+		if priorProvider == nil {
+			priorProvider = &eventSinkProviderModel{}
+		}
+		item := eventSinkProviderModel{
+			ProviderName:    types.StringValue(name),
+			Kafka:           []kafkaSinkModel{},
+			AzureEventGrid:  []azureEventGridModel{},
+			AzureServiceBus: []azureServiceBusModel{},
+			PubSub:          []pubSubSinkModel{},
+		}
+		if data, ok := pickMap(providerData, "kafka"); ok {
+			sink := kafkaSinkModel{
+				Topic:               stringOrNull(data["topic"]),
+				Username:            stringOrNull(data["username"]),
+				DisableTLS:          types.BoolValue(getEither(data, "disable_tls", "disableTls") == true),
+				TLSSkipVerify:       types.BoolValue(getEither(data, "tls_skip_verify", "tlsSkipVerify") == true),
+				ProviderDisplayName: stringOrNull(getEither(data, "display_name", "displayName")),
+				LastError:           stringOrNull(getEither(data, "last_error", "lastError")),
+				Password:            types.StringNull(),
+				PasswordWO:          types.StringNull(),
+				PasswordWOVersion:   types.Int64Null(),
+			}
+			brokers, _ := data["brokers"].([]any)
+			sink.Brokers = make([]types.String, 0, len(brokers))
+			for _, broker := range brokers {
+				sink.Brokers = append(sink.Brokers, types.StringValue(fmt.Sprint(broker)))
+			}
+			if len(priorProvider.Kafka) > 0 {
+				sink.Password = priorProvider.Kafka[0].Password
+				sink.PasswordWOVersion = priorProvider.Kafka[0].PasswordWOVersion
+			}
+			item.Kafka = append(item.Kafka, sink)
 		}
-		if gridData, ok := pickMap(providerData, "azure_event_grid", "azureEventGrid"); ok {
-			results = append(results, map[string]any{
-				providerNameKey:   key,
-				azureEventGridKey: []any{buildAzureEventGridConfig(gridData, data)},
-			})
+		if data, ok := pickMap(providerData, "azure_event_grid", "azureEventGrid"); ok {
+			sink := azureEventGridModel{
+				TopicEndpoint:       stringOrNull(getEither(data, "topic_endpoint", "topicEndpoint")),
+				ProviderDisplayName: stringOrNull(getEither(data, "display_name", "displayName")),
+				LastError:           stringOrNull(getEither(data, "last_error", "lastError")),
+				AccessKey:           types.StringNull(),
+				AccessKeyWO:         types.StringNull(),
+				AccessKeyWOVersion:  types.Int64Null(),
+			}
+			if len(priorProvider.AzureEventGrid) > 0 {
+				sink.AccessKey = priorProvider.AzureEventGrid[0].AccessKey
+				sink.AccessKeyWOVersion = priorProvider.AzureEventGrid[0].AccessKeyWOVersion
+			}
+			item.AzureEventGrid = append(item.AzureEventGrid, sink)
 		}
-		if busData, ok := pickMap(providerData, "azure_service_bus", "azureServiceBus"); ok {
-			results = append(results, map[string]any{
-				providerNameKey:    key,
-				azureServiceBusKey: []any{buildAzureServiceBusConfig(busData, data)},
-			})
+		if data, ok := pickMap(providerData, "azure_service_bus", "azureServiceBus"); ok {
+			sink := azureServiceBusModel{
+				QueueOrTopicName:          stringOrNull(getEither(data, "queue_or_topic_name", "queueOrTopicName")),
+				ProviderDisplayName:       stringOrNull(getEither(data, "display_name", "displayName")),
+				LastError:                 stringOrNull(getEither(data, "last_error", "lastError")),
+				ConnectionString:          types.StringNull(),
+				ConnectionStringWO:        types.StringNull(),
+				ConnectionStringWOVersion: types.Int64Null(),
+			}
+			if len(priorProvider.AzureServiceBus) > 0 {
+				sink.ConnectionString = priorProvider.AzureServiceBus[0].ConnectionString
+				sink.ConnectionStringWOVersion = priorProvider.AzureServiceBus[0].ConnectionStringWOVersion
+			}
+			item.AzureServiceBus = append(item.AzureServiceBus, sink)
 		}
-		if pubsubData, ok := pickMap(providerData, "pubsub", "pubSub"); ok {
-			results = append(results, map[string]any{
-				providerNameKey: key,
-				pubsubKey:       []any{buildPubSubConfig(pubsubData, data)},
-			})
+		if data, ok := pickMap(providerData, "pubsub", "pubSub"); ok {
+			sink := pubSubSinkModel{
+				ProjectID:                stringOrNull(getEither(data, "project_id", "projectId")),
+				TopicName:                stringOrNull(getEither(data, "topic_name", "topicName")),
+				ProviderDisplayName:      stringOrNull(getEither(data, "display_name", "displayName")),
+				LastError:                stringOrNull(getEither(data, "last_error", "lastError")),
+				CredentialsJSON:          types.StringNull(),
+				CredentialsJSONWO:        types.StringNull(),
+				CredentialsJSONWOVersion: types.Int64Null(),
+			}
+			if len(priorProvider.PubSub) > 0 {
+				sink.CredentialsJSON = priorProvider.PubSub[0].CredentialsJSON
+				sink.CredentialsJSONWOVersion = priorProvider.PubSub[0].CredentialsJSONWOVersion
+			}
+			item.PubSub = append(item.PubSub, sink)
 		}
+		results = append(results, item)
 	}
 	return results
 }
 
-// flattenEventSinkRoutes converts the API routes list into Terraform schema blocks.
-func flattenEventSinkRoutes(routesList []any) []any {
-	routes := make([]any, len(routesList))
+// flattenEventSinkRoutes converts the API routes list into the model.
+func flattenEventSinkRoutes(routesList []any) []eventSinkRouteModel {
+	routes := make([]eventSinkRouteModel, len(routesList))
 	for i, r := range routesList {
 		routeData, _ := r.(map[string]any)
-		routes[i] = flattenEventSinkRoute(routeData)
-	}
-	return routes
-}
-
-// flattenEventSinkRoute converts a single API route into a Terraform schema block.
-func flattenEventSinkRoute(routeData map[string]any) map[string]any {
-	routeMap := map[string]any{
-		providerIDKey:     getEither(routeData, "provider_id", "providerId"),
-		stopProcessingKey: getEither(routeData, "stop_processing", "stopProcessing"),
-		routeDisplayKey:   getEither(routeData, "display_name", "displayName"),
-		routeIDKey:        routeData["id"],
-	}
-
-	kvData, ok := pickMap(routeData, "event_type_key_values_filter", "keysValues")
-	if !ok {
-		return routeMap
-	}
-
-	pairsList, _ := getEither(kvData, "key_value_pairs", "keyValuePairs").([]any)
-	keyValuePairs := make([]any, len(pairsList))
-	for j, pair := range pairsList {
-		pairData, _ := pair.(map[string]any)
-		keyValuePairs[j] = map[string]any{
-			keyKey:   pairData["key"],
-			valueKey: pairData["value"],
+		routes[i] = eventSinkRouteModel{
+			ProviderID:       stringOrNull(getEither(routeData, "provider_id", "providerId")),
+			StopProcessing:   types.BoolValue(getEither(routeData, "stop_processing", "stopProcessing") == true),
+			RouteDisplayName: stringOrNull(getEither(routeData, "display_name", "displayName")),
+			RouteID:          stringOrNull(routeData["id"]),
+			KeysValuesFilter: []keysValuesFilterModel{},
 		}
-	}
-	routeMap[keysValuesKey] = []map[string]any{
-		{
-			keyValuePairsKey: keyValuePairs,
-			evTypeKey:        getEither(kvData, "event_type", "eventType"),
-		},
-	}
-	return routeMap
-}
 
-func validateProviderOneOf(providerTypes []string) schema.CustomizeDiffFunc {
-	return func(_ context.Context, d *schema.ResourceDiff, _ any) error {
-		providers := d.Get(providersKey).([]any)
-		for i, p := range providers {
-			if p == nil {
-				continue
-			}
-			providerMap := p.(map[string]any)
-			count := 0
-			for _, value := range providerTypes {
-				if _, ok := providerMap[value]; ok && providerMap[value] != nil {
-					switch v := providerMap[value].(type) {
-					case []any:
-						if len(v) > 0 {
-							count++
-						}
-					case []string:
-						if len(v) > 0 {
-							count++
-						}
-					}
-				}
-			}
-			if count != 1 {
-				return fmt.Errorf("exactly one of providers must be specified in providers[%d]", i)
+		kvData, ok := pickMap(routeData, "event_type_key_values_filter", "keysValues")
+		if !ok {
+			continue
+		}
+		pairsList, _ := getEither(kvData, "key_value_pairs", "keyValuePairs").([]any)
+		pairs := make([]keyValuePairModel, len(pairsList))
+		for j, pair := range pairsList {
+			pairData, _ := pair.(map[string]any)
+			pairs[j] = keyValuePairModel{
+				Key:   stringOrNull(pairData["key"]),
+				Value: stringOrNull(pairData["value"]),
 			}
 		}
-		return nil
+		routes[i].KeysValuesFilter = append(routes[i].KeysValuesFilter, keysValuesFilterModel{
+			KeyValuePairs: pairs,
+			EventType:     stringOrNull(getEither(kvData, "event_type", "eventType")),
+		})
 	}
+	return routes
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -483,9 +484,7 @@ var _ = Describe("Resource EventSink", func() {
 		}`
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				// Errors case must be always first
 				{
@@ -494,7 +493,7 @@ var _ = Describe("Resource EventSink", func() {
 				},
 				{
 					Config:      fmt.Sprintf(tfConfigDef, appSpaceID, "name", ``),
-					ExpectError: regexp.MustCompile(`At least 1 "providers" blocks are required.`),
+					ExpectError: regexp.MustCompile(`providers list must contain at least 1 elements`),
 				},
 				{
 					Config: fmt.Sprintf(tfConfigDef, appSpaceID, "name", `
@@ -592,9 +591,7 @@ var _ = Describe("Resource EventSink", func() {
 		currentConfig = "azuregrid"
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					// Azure Event Grid
@@ -632,9 +629,7 @@ var _ = Describe("Resource EventSink", func() {
 		currentConfig = "azurebus"
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					// Azure Service Bus
@@ -675,9 +670,7 @@ var _ = Describe("Resource EventSink", func() {
 		currentConfig = "pubsub"
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					// Google Cloud Pub/Sub
@@ -839,9 +832,7 @@ var _ = Describe("Resource EventSink", func() {
 		}
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					Config: tfConfigDef,
@@ -1021,9 +1012,7 @@ var _ = Describe("Resource EventSink", func() {
 		}
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					// Create with CDC enabled.
@@ -1174,9 +1163,7 @@ var _ = Describe("Resource EventSink", func() {
 		}
 
 		resource.Test(GinkgoT(), resource.TestCase{
-			Providers: map[string]*schema.Provider{
-				"indykite": provider,
-			},
+			ProtoV6ProviderFactories: indykitetest.ProtoV6ProviderFactories(provider),
 			Steps: []resource.TestStep{
 				{
 					Config: fmt.Sprintf(tfConfigDef, appSpaceID),
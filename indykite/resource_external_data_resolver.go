@@ -46,9 +46,7 @@ func resourceExternalDataResolver() *schema.Resource {
 		ReadContext:   resExternalDataResolverRead,
 		UpdateContext: resExternalDataResolverUpdate,
 		DeleteContext: resExternalDataResolverDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      externalDataResolverImport.importer(),
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -61,6 +59,7 @@ func resourceExternalDataResolver() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 
 			externalDataResolverURLKey: {
 				Type:     schema.TypeString,
@@ -182,7 +181,7 @@ func resExternalDataResolverRead(ctx context.Context, data *schema.ResourceData,
 
 	var resp ExternalDataResolverResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/external-data-resolvers", data)
+	path := buildReadPath(externalDataResolverImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -209,6 +208,7 @@ func resExternalDataResolverRead(ctx context.Context, data *schema.ResourceData,
 	}
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 
 	setData(&d, data, externalDataResolverURLKey, resp.URL)
 	setData(&d, data, externalDataResolverMethodKey, resp.Method)
@@ -277,7 +277,9 @@ func resExternalDataResolverUpdate(ctx context.Context, data *schema.ResourceDat
 	}
 
 	var resp ExternalDataResolverResponse
-	err := clientCtx.GetClient().Put(ctx, "/external-data-resolvers/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/external-data-resolvers/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -294,7 +296,7 @@ func resExternalDataResolverDelete(ctx context.Context, data *schema.ResourceDat
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/external-data-resolvers/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/external-data-resolvers/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
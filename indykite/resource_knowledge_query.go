@@ -28,6 +28,7 @@ const (
 	knowledgeQueryJSONQueryConfigKey = "query"
 	knowledgeQueryStatusKey          = "status"
 	knowledgeQueryPolicyID           = "policy_id"
+	knowledgeQuerySkipPolicyCheckKey = "skip_policy_check"
 )
 
 func resourceKnowledgeQuery() *schema.Resource {
@@ -54,9 +55,8 @@ func resourceKnowledgeQuery() *schema.Resource {
 		ReadContext:   resKnowledgeQueryRead,
 		UpdateContext: resKnowledgeQueryUpdate,
 		DeleteContext: resKnowledgeQueryDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      knowledgeQueryImport.importer(),
+		CustomizeDiff: knowledgeQueryCustomizeDiff,
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -68,6 +68,7 @@ func resourceKnowledgeQuery() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 
 			knowledgeQueryJSONQueryConfigKey: {
 				Type:             schema.TypeString,
@@ -90,7 +91,16 @@ func resourceKnowledgeQuery() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				ValidateDiagFunc: ValidateGID,
-				Description:      "ID of the Authorization Policy that is used to authorize the query.",
+				Description: "ID of the Authorization Policy that is used to authorize the query. " +
+					"Nodes and relationships of the query must refer to variables of the policy cypher, " +
+					"which is checked at plan time against the policy as it currently exists on the server.",
+			},
+			knowledgeQuerySkipPolicyCheckKey: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Skip the plan time check of the query against the policy cypher. " +
+					"The check reads the policy as it currently exists on the server, so set this " +
+					"when the policy and the query are changed in the same run, like renaming a variable.",
 			},
 		},
 	}
@@ -140,7 +150,7 @@ func resKnowledgeQueryRead(ctx context.Context, data *schema.ResourceData, meta
 
 	var resp KnowledgeQueryResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/knowledge-queries", data)
+	path := buildReadPath(knowledgeQueryImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -162,6 +172,7 @@ func resKnowledgeQueryRead(ctx context.Context, data *schema.ResourceData, meta
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, knowledgeQueryJSONQueryConfigKey, resp.Query)
 
 	// Map status from API format to Terraform format
@@ -193,7 +204,9 @@ func resKnowledgeQueryUpdate(ctx context.Context, data *schema.ResourceData, met
 	}
 
 	var resp KnowledgeQueryResponse
-	err := clientCtx.GetClient().Put(ctx, "/knowledge-queries/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/knowledge-queries/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -210,7 +223,7 @@ func resKnowledgeQueryDelete(ctx context.Context, data *schema.ResourceData, met
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/knowledge-queries/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/knowledge-queries/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
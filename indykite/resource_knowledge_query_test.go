@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -31,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -434,3 +436,186 @@ func testKnowledgeQueryResourceDataExists(
 		return convertOmegaMatcherToError(MatchKeys(IgnoreExtras, keys), attrs)
 	}
 }
+
+var _ = Describe("Resource Knowledge Query policy references", func() {
+	var (
+		server    *indykitetest.Server
+		provider  *schema.Provider
+		res       *schema.Resource
+		projectID string
+		policyID  string
+		ctx       = context.Background()
+	)
+
+	BeforeEach(func() {
+		server = indykitetest.NewServer()
+		DeferCleanup(server.Close)
+		provider = server.Provider()
+		Expect(provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		res = provider.ResourcesMap["indykite_knowledge_query"]
+
+		var err error
+		projectID, err = server.Seed(indykitetest.Projects, map[string]any{
+			"organization_id": server.OrganizationID(), "name": "project",
+		})
+		Expect(err).To(Succeed())
+		policyID, err = server.Seed(indykitetest.AuthorizationPolicies, map[string]any{
+			"project_id": projectID, "name": "policy", "status": "ACTIVE",
+			"policy": `{"meta":{"policy_version":"1.0-ciq"},"subject":{"type":"Person"},"condition":{"cypher":` +
+				`"MATCH (subject:Person)-[r1:ACCEPTED]->(contract:Contract)-[:COVERS]->(vehicle:Vehicle)` +
+				`-[r3:HAS]->(ln:LicenseNumber)"}}`,
+		})
+		Expect(err).To(Succeed())
+	})
+
+	planWith := func(state *terraform.InstanceState, config map[string]any) error {
+		raw := map[string]any{"name": "query", "location": projectID, "status": "active"}
+		maps.Copy(raw, config)
+		_, err := res.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), provider.Meta())
+		return err
+	}
+	plan := func(state *terraform.InstanceState, query, policy string) error {
+		return planWith(state, map[string]any{"query": query, "policy_id": policy})
+	}
+
+	DescribeTable("checks query against policy cypher",
+		func(query string, matcher OmegaMatcher) {
+			Expect(plan(nil, query, policyID)).To(matcher)
+		},
+		Entry("known variables",
+			`{"nodes":["ln.property.value","subject.*","vehicle"],"relationships":["r1","r3"],`+
+				`"filter":{"operator":"AND","operands":[`+
+				`{"attribute":"ln.property.value","operator":"=","value":"$ln"},`+
+				`{"attribute":"$token.sub","operator":"=","value":"$sub"}]}}`,
+			Succeed()),
+		Entry("relationship properties",
+			`{"nodes":["ln"],"relationships":["r1.property.since","r3.*"],`+
+				`"filter":{"attribute":"r1.property.since","operator":">","value":"$since"}}`,
+			Succeed()),
+		Entry("upserted relationship between known and upserted nodes",
+			`{"nodes":["contract.external_id"],"upsert_nodes":[{"name":"payment","type":"PaymentMethod"}],`+
+				`"upsert_relationships":[{"name":"rel","source":"subject","target":"payment","type":"HAS"}]}`,
+			Succeed()),
+		Entry("unknown node variable", `{"nodes":["ln.property.value","Person.property.email"]}`,
+			MatchError(And(
+				ContainSubstring("query does not match policy gid:"),
+				ContainSubstring(`nodes[1] refers to unknown node variable "Person", `+
+					"policy defines: contract, ln, subject, vehicle"),
+			))),
+		Entry("unknown relationship variable", `{"nodes":["ln.property.value"],"relationships":["r2"]}`,
+			MatchError(ContainSubstring(`relationships[0] refers to unknown relationship variable "r2", `+
+				"policy defines: r1, r3"))),
+		Entry("unknown relationship variable with property",
+			`{"nodes":["ln"],"relationships":["r2.property.since"]}`,
+			MatchError(ContainSubstring(`relationships[0] refers to unknown relationship variable "r2", `))),
+		Entry("unknown variable in nested filter",
+			`{"nodes":["ln"],"filter":{"operator":"OR","operands":[`+
+				`{"attribute":"car.property.vin","operator":"=","value":"$v"}]}}`,
+			MatchError(ContainSubstring(`filter.operands[0].attribute refers to unknown variable "car", `+
+				"policy defines nodes: contract, ln, subject, vehicle; relationships: r1, r3"))),
+	)
+
+	It("skips the check when policy is not known yet or does not exist", func() {
+		// Marker of unknown values in raw configuration
+		const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+		Expect(plan(nil, `{"nodes":["car.property.vin"]}`, unknownValue)).To(Succeed())
+		Expect(plan(nil, `{"nodes":["car.property.vin"]}`, "gid:AAAAAmluZHlraURlgAABDwAAAAA")).To(Succeed())
+	})
+
+	It("skips the check when policy cannot be read", func() {
+		server.InjectFault(indykitetest.Fault{
+			Method: http.MethodGet, Path: "/authorization-policies", Status: http.StatusForbidden,
+		})
+		Expect(plan(nil, `{"nodes":["car.property.vin"]}`, policyID)).To(Succeed())
+	})
+
+	It("checks policy as it exists on the server unless skip_policy_check is set", func() {
+		// The policy renames ln to lic in the same run, but the server still has the old cypher
+		query := `{"nodes":["lic.property.value"]}`
+		Expect(plan(nil, query, policyID)).To(MatchError(ContainSubstring(`unknown node variable "lic"`)))
+		Expect(planWith(nil, map[string]any{
+			"query": query, "policy_id": policyID, "skip_policy_check": true,
+		})).To(Succeed())
+	})
+
+	It("checks only changed queries", func() {
+		queryID, err := server.Seed(indykitetest.KnowledgeQueries, map[string]any{
+			"project_id": projectID, "name": "query", "status": "ACTIVE", "policy_id": policyID,
+			"query": `{"nodes":["car.property.vin"]}`,
+		})
+		Expect(err).To(Succeed())
+		data := res.TestResourceData()
+		data.SetId(queryID)
+		Expect(res.ReadContext(ctx, data, provider.Meta())).To(BeEmpty())
+
+		Expect(plan(data.State(), `{"nodes":["car.property.vin"]}`, policyID)).To(Succeed())
+		Expect(plan(data.State(), `{"nodes":["car.property.model"]}`, policyID)).To(
+			MatchError(ContainSubstring(`unknown node variable "car"`)))
+	})
+
+	It("fails the plan and applies the fixed query against the fake server", func() {
+		const resourceName = "indykite_knowledge_query.wonka"
+		tfConfig := `resource "indykite_knowledge_query" "wonka" {
+			location  = "` + projectID + `"
+			name      = "wonka-query"
+			status    = "active"
+			policy_id = "` + policyID + `"
+			query     = jsonencode({nodes = [%q]})
+		}
+		`
+		resource.Test(GinkgoT(), resource.TestCase{
+			ProtoV6ProviderFactories: server.ProtoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(tfConfig, "car.property.vin"),
+					ExpectError: regexp.MustCompile(`unknown node variable "car"`),
+				},
+				{
+					Config: fmt.Sprintf(tfConfig, "ln.property.value"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^gid:`)),
+						resource.TestCheckResourceAttr(resourceName, "policy_id", policyID),
+						resource.TestCheckResourceAttr(resourceName, "query", `{"nodes":["ln.property.value"]}`),
+					),
+				},
+			},
+		})
+	})
+
+	It("applies policy and query changed in the same run with skip_policy_check", func() {
+		tfConfig := `resource "indykite_authorization_policy" "policy" {
+			location = "` + projectID + `"
+			name     = "vehicle-policy"
+			status   = "active"
+			json     = jsonencode({
+				meta      = { policy_version = "1.0-ciq" }
+				subject   = { type = "Person" }
+				condition = { cypher = "MATCH (subject:Person)-[:HAS]->(%[1]s:LicenseNumber)" }
+			})
+		}
+		resource "indykite_knowledge_query" "wonka" {
+			location          = "` + projectID + `"
+			name              = "wonka-query"
+			status            = "active"
+			policy_id         = indykite_authorization_policy.policy.id
+			query             = jsonencode({nodes = ["%[1]s.property.value"]})
+			skip_policy_check = %[2]t
+		}
+		`
+		resource.Test(GinkgoT(), resource.TestCase{
+			ProtoV6ProviderFactories: server.ProtoV6ProviderFactories(),
+			Steps: []resource.TestStep{
+				{Config: fmt.Sprintf(tfConfig, "ln", false)},
+				{
+					Config:      fmt.Sprintf(tfConfig, "lic", false),
+					ExpectError: regexp.MustCompile(`unknown node variable "lic"`),
+				},
+				{
+					Config: fmt.Sprintf(tfConfig, "lic", true),
+					Check: resource.TestCheckResourceAttr("indykite_knowledge_query.wonka", "query",
+						`{"nodes":["lic.property.value"]}`),
+				},
+			},
+		})
+	})
+})
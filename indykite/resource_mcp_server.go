@@ -37,9 +37,7 @@ func resourceMCPServer() *schema.Resource {
 		ReadContext:   resMCPServerRead,
 		UpdateContext: resMCPServerUpdate,
 		DeleteContext: resMCPServerDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      mcpServerImport.importer(),
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -52,6 +50,7 @@ func resourceMCPServer() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 			createdByKey:   createdBySchema(),
 			updatedByKey:   updatedBySchema(),
 
@@ -126,7 +125,7 @@ func resMCPServerRead(ctx context.Context, data *schema.ResourceData, meta any)
 	defer cancel()
 
 	var resp MCPServerResponse
-	path := buildReadPath("/mcp-servers", data)
+	path := buildReadPath(mcpServerImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -147,6 +146,7 @@ func resMCPServerRead(ctx context.Context, data *schema.ResourceData, meta any)
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, createdByKey, resp.CreatedBy)
 	setData(&d, data, updatedByKey, resp.UpdatedBy)
 
@@ -177,7 +177,7 @@ func resMCPServerUpdate(ctx context.Context, data *schema.ResourceData, meta any
 	}
 
 	var resp MCPServerResponse
-	err := clientCtx.GetClient().Put(ctx, "/mcp-servers/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(ctx, "/mcp-servers/"+data.Id(), data.Get(etagKey).(string), req, &resp)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -194,7 +194,7 @@ func resMCPServerDelete(ctx context.Context, data *schema.ResourceData, meta any
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/mcp-servers/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/mcp-servers/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
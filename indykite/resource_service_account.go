@@ -33,10 +33,8 @@ func resourceServiceAccount() *schema.Resource {
 		ReadContext:   resServiceAccountReadContext,
 		UpdateContext: resServiceAccountUpdateContext,
 		DeleteContext: resServiceAccountDeleteContext,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
-		Timeouts: defaultTimeouts("update"),
+		Importer:      serviceAccountImport.importer(),
+		Timeouts:      defaultTimeouts("update"),
 		Schema: map[string]*schema.Schema{
 			customerIDKey:         customerIDSchema(),
 			nameKey:               nameSchema(),
@@ -44,6 +42,7 @@ func resourceServiceAccount() *schema.Resource {
 			descriptionKey:        descriptionSchema(),
 			createTimeKey:         createTimeSchema(),
 			updateTimeKey:         updateTimeSchema(),
+			etagKey:               etagSchema(),
 			deletionProtectionKey: deletionProtectionSchema(),
 			roleKey:               roleSchema(),
 		},
@@ -100,7 +99,7 @@ func resServiceAccountReadContext(ctx context.Context, data *schema.ResourceData
 
 	var resp ServiceAccountResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/service-accounts", data)
+	path := buildReadPath(serviceAccountImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -114,6 +113,7 @@ func resServiceAccountReadContext(ctx context.Context, data *schema.ResourceData
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, roleKey, resp.Role)
 
 	return d
@@ -134,7 +134,7 @@ func resServiceAccountUpdateContext(ctx context.Context, data *schema.ResourceDa
 	}
 
 	var resp ServiceAccountResponse
-	err := clientCtx.GetClient().Put(ctx, "/service-accounts/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(ctx, "/service-accounts/"+data.Id(), data.Get(etagKey).(string), req, &resp)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -155,7 +155,7 @@ func resServiceAccountDeleteContext(ctx context.Context, data *schema.ResourceDa
 		return d
 	}
 
-	err := clientCtx.GetClient().Delete(ctx, "/service-accounts/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/service-accounts/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 
 	return d
@@ -16,6 +16,7 @@ package indykite
 
 import (
 	"context"
+	"maps"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -28,16 +29,21 @@ const (
 	serviceAccountConfigKey = "service_account_config"
 )
 
+var serviceAccountCredRotation = credentialRotation{
+	path:      "/service-account-credentials/",
+	configKey: serviceAccountConfigKey,
+}
+
 func resourceServiceAccountCredential() *schema.Resource {
-	return &schema.Resource{
+	res := &schema.Resource{
 		Description:   "Service Account Credential is a JSON configuration file that contains a secret key or token for authenticating to IndyKite APIs.",
 		CreateContext: resServiceAccountCredCreate,
 		ReadContext:   resServiceAccountCredRead,
+		UpdateContext: resServiceAccountCredUpdate,
 		DeleteContext: resServiceAccountCredDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
-		Timeouts: defaultTimeouts("update"),
+		CustomizeDiff: serviceAccountCredRotation.customizeDiff,
+		Importer:      serviceAccountCredImport.importer(),
+		Timeouts:      defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			customerIDKey: setComputed(customerIDSchema()),
 			serviceAccountIDKey: {
@@ -57,10 +63,11 @@ func resourceServiceAccountCredential() *schema.Resource {
 			expireTimeKey: {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
+				Computed:         true,
 				ValidateFunc:     validation.IsRFC3339Time,
 				DiffSuppressFunc: ExpireTimeDiffSuppress,
-				Description:      "Optional date-time when credentials are going to expire in RFC3339 format.",
+				Description: "Optional date-time when credentials are going to expire in RFC3339 format. " +
+					"Any change replaces the credential.",
 			},
 			kidKey: {
 				Type:        schema.TypeString,
@@ -73,9 +80,13 @@ func resourceServiceAccountCredential() *schema.Resource {
 				Sensitive:   true,
 				Description: "JSON configuration of the created credential. This is only available after creation.",
 			},
-			createTimeKey: createTimeSchema(),
+			createTimeKey:      createTimeSchema(),
+			rotationKey:        rotationSchema(),
+			rotationTriggerKey: rotationTriggerSchema(),
 		},
 	}
+	maps.Copy(res.Schema, serviceAccountCredRotation.schema())
+	return res
 }
 
 func resServiceAccountCredCreate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
@@ -87,31 +98,39 @@ func resServiceAccountCredCreate(ctx context.Context, data *schema.ResourceData,
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
-	req := CreateServiceAccountCredentialRequest{
-		ServiceAccountID: data.Get(serviceAccountIDKey).(string),
-		DisplayName:      data.Get(displayNameKey).(string),
-	}
-
-	if expRaw, ok := data.GetOk(expireTimeKey); ok {
-		exp, err := time.Parse(time.RFC3339, expRaw.(string))
+	expireTime := newExpireTime(data)
+	if expireTime != "" {
+		exp, err := time.Parse(time.RFC3339, expireTime)
 		if err != nil {
 			return append(d, buildPluginErrorWithAttrName(err.Error(), expireTimeKey))
 		}
-		req.ExpireTime = exp.Format(time.RFC3339)
+		expireTime = exp.Format(time.RFC3339)
 	}
 
-	var resp ServiceAccountCredentialResponse
-	err := clientCtx.GetClient().Post(ctx, "/service-account-credentials", req, &resp)
+	id, config, err := createServiceAccountCredential(clientCtx.GetClient(), data)(ctx, expireTime)
 	if HasFailed(&d, err) {
 		return d
 	}
-	data.SetId(resp.ID)
-	setData(&d, data, serviceAccountIDKey, resp.ServiceAccountID)
-	setData(&d, data, serviceAccountConfigKey, resp.ServiceAccountConfig)
+	data.SetId(id)
+	setData(&d, data, serviceAccountConfigKey, config)
 
 	return resServiceAccountCredRead(ctx, data, meta)
 }
 
+// createServiceAccountCredential returns function creating credential of the configured service account.
+func createServiceAccountCredential(client *RestClient, data *schema.ResourceData) createCredentialFunc {
+	return func(ctx context.Context, expireTime string) (string, string, error) {
+		req := CreateServiceAccountCredentialRequest{
+			ServiceAccountID: data.Get(serviceAccountIDKey).(string),
+			DisplayName:      data.Get(displayNameKey).(string),
+			ExpireTime:       expireTime,
+		}
+		var resp ServiceAccountCredentialResponse
+		err := client.Post(ctx, "/service-account-credentials", req, &resp)
+		return resp.ID, resp.ServiceAccountConfig, err
+	}
+}
+
 func resServiceAccountCredRead(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
 	clientCtx := getClientContext(&d, meta)
@@ -134,15 +153,39 @@ func resServiceAccountCredRead(ctx context.Context, data *schema.ResourceData, m
 	setData(&d, data, serviceAccountIDKey, resp.ServiceAccountID)
 	setData(&d, data, displayNameKey, resp.DisplayName)
 	setData(&d, data, kidKey, resp.Kid)
-	setData(&d, data, serviceAccountConfigKey, resp.ServiceAccountConfig)
+	// Config is returned only on creation, keep the one stored in the state otherwise
+	if resp.ServiceAccountConfig != "" {
+		setData(&d, data, serviceAccountConfigKey, resp.ServiceAccountConfig)
+	}
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	if resp.ExpireTime != "" {
 		setData(&d, data, expireTimeKey, resp.ExpireTime)
 	}
+	clientCtx.warnCredentialExpiry(&d, resp.ID, resp.Kid, parseExpireTime(resp.ExpireTime))
 
 	return d
 }
 
+func resServiceAccountCredUpdate(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
+	var d diag.Diagnostics
+	clientCtx := getClientContext(&d, meta)
+	if clientCtx == nil {
+		return d
+	}
+	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	client := clientCtx.GetClient()
+	config := serviceAccountCredRotation.update(ctx, &d, client, data, createServiceAccountCredential(client, data))
+	if d.HasError() {
+		return d
+	}
+	if config != "" {
+		setData(&d, data, serviceAccountConfigKey, config)
+	}
+	return append(d, resServiceAccountCredRead(ctx, data, meta)...)
+}
+
 func resServiceAccountCredDelete(ctx context.Context, data *schema.ResourceData, meta any) diag.Diagnostics {
 	var d diag.Diagnostics
 	clientCtx := getClientContext(&d, meta)
@@ -154,7 +197,6 @@ func resServiceAccountCredDelete(ctx context.Context, data *schema.ResourceData,
 	}
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
-	err := clientCtx.GetClient().Delete(ctx, "/service-account-credentials/"+data.Id())
-	HasFailed(&d, err)
+	serviceAccountCredRotation.delete(ctx, &d, clientCtx.GetClient(), data)
 	return d
 }
@@ -66,9 +66,7 @@ func resourceTokenIntrospect() *schema.Resource {
 		ReadContext:   resTokenIntrospectRead,
 		UpdateContext: resTokenIntrospectUpdate,
 		DeleteContext: resTokenIntrospectDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      tokenIntrospectImport.importer(),
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -81,6 +79,7 @@ func resourceTokenIntrospect() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 			createdByKey:   createdBySchema(),
 			updatedByKey:   updatedBySchema(),
 
@@ -244,7 +243,7 @@ func resTokenIntrospectRead(ctx context.Context, data *schema.ResourceData, meta
 
 	var resp TokenIntrospectResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/token-introspects", data)
+	path := buildReadPath(tokenIntrospectImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -266,6 +265,7 @@ func resTokenIntrospectRead(ctx context.Context, data *schema.ResourceData, meta
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, createdByKey, resp.CreatedBy)
 	setData(&d, data, updatedByKey, resp.UpdatedBy)
 
@@ -341,7 +341,9 @@ func resTokenIntrospectUpdate(ctx context.Context, data *schema.ResourceData, me
 	req.SubClaim = tokenReq.SubClaim
 
 	var resp TokenIntrospectResponse
-	err := clientCtx.GetClient().Put(ctx, "/token-introspects/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/token-introspects/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -358,7 +360,7 @@ func resTokenIntrospectDelete(ctx context.Context, data *schema.ResourceData, me
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/token-introspects/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/token-introspects/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
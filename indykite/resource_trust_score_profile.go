@@ -45,9 +45,7 @@ func resourceTrustScoreProfile() *schema.Resource {
 		ReadContext:   resTrustScoreProfileRead,
 		UpdateContext: resTrustScoreProfileUpdate,
 		DeleteContext: resTrustScoreProfileDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: basicStateImporter,
-		},
+		Importer:      trustScoreProfileImport.importer(),
 
 		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
@@ -60,6 +58,7 @@ func resourceTrustScoreProfile() *schema.Resource {
 			descriptionKey: descriptionSchema(),
 			createTimeKey:  createTimeSchema(),
 			updateTimeKey:  updateTimeSchema(),
+			etagKey:        etagSchema(),
 
 			trustScoreProfileNodeClassification: {
 				Type:        schema.TypeString,
@@ -158,7 +157,7 @@ func resTrustScoreProfileRead(ctx context.Context, data *schema.ResourceData, me
 
 	var resp TrustScoreProfileResponse
 	// Support both ID and name?location=parent_id formats
-	path := buildReadPath("/trust-score-profiles", data)
+	path := buildReadPath(trustScoreProfileImport, data)
 	err := clientCtx.GetClient().Get(ctx, path, &resp)
 	if readHasFailed(&d, err, data) {
 		return d
@@ -180,6 +179,7 @@ func resTrustScoreProfileRead(ctx context.Context, data *schema.ResourceData, me
 	setData(&d, data, descriptionKey, resp.Description)
 	setData(&d, data, createTimeKey, resp.CreateTime)
 	setData(&d, data, updateTimeKey, resp.UpdateTime)
+	setData(&d, data, etagKey, resp.Etag)
 	setData(&d, data, trustScoreProfileNodeClassification, resp.NodeClassification)
 
 	// Convert dimensions
@@ -228,7 +228,9 @@ func resTrustScoreProfileUpdate(ctx context.Context, data *schema.ResourceData,
 	}
 
 	var resp TrustScoreProfileResponse
-	err := clientCtx.GetClient().Put(ctx, "/trust-score-profiles/"+data.Id(), req, &resp)
+	err := clientCtx.GetClient().PutIfMatch(
+		ctx, "/trust-score-profiles/"+data.Id(), data.Get(etagKey).(string), req, &resp,
+	)
 	if HasFailed(&d, err) {
 		return d
 	}
@@ -245,7 +247,7 @@ func resTrustScoreProfileDelete(ctx context.Context, data *schema.ResourceData,
 	ctx, cancel := context.WithTimeout(ctx, data.Timeout(schema.TimeoutDelete))
 	defer cancel()
 
-	err := clientCtx.GetClient().Delete(ctx, "/trust-score-profiles/"+data.Id())
+	err := clientCtx.GetClient().DeleteIfMatch(ctx, "/trust-score-profiles/"+data.Id(), data.Get(etagKey).(string))
 	HasFailed(&d, err)
 	return d
 }
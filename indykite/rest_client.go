@@ -15,17 +15,18 @@
 package indykite
 
 import (
-	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -36,48 +37,161 @@ import (
 // RestClient wraps HTTP client for IndyKite Config REST API.
 type RestClient struct {
 	httpClient *http.Client
+	tokens     tokenSource
+	throttle   *throttledTransport
 	baseURL    string
-	token      string
+	retry      RetryConfig
 }
 
-// NewRestClient creates a new REST client for IndyKite Config API.
-func NewRestClient(_ context.Context) (*RestClient, error) {
-	// Get service account credentials from environment
-	credentials := os.Getenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS")
-	credsFile := os.Getenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE")
+// Defaults used when neither provider configuration nor credentials specify otherwise.
+const (
+	defaultRegion           = "eu"
+	defaultRequestTimeout   = 2 * time.Minute
+	defaultRetryMaxAttempts = 4
+	defaultRetryMinBackoff  = 1 * time.Second
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
 
-	if credentials == "" && credsFile != "" {
-		// #nosec G304,G703 -- credsFile is from environment variable, intentional file read
-		data, err := os.ReadFile(credsFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read credentials file: %w", err)
-		}
-		credentials = string(data)
+// RestClientConfig holds the settings used to build a RestClient.
+// Empty credentials fall back to the INDYKITE_SERVICE_ACCOUNT_CREDENTIALS and
+// INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE environment variables.
+type RestClientConfig struct {
+	// Credentials is the content of the service account credentials file.
+	Credentials string
+	// CredentialsFile is the path to the service account credentials file.
+	CredentialsFile string
+	// BaseURL overrides the endpoint from credentials, e.g. "https://eu.api.indykite.com".
+	BaseURL string
+	// Region selects the regional endpoint ("eu" or "us") when BaseURL is not set.
+	Region string
+	// RequestTimeout bounds every single HTTP request; defaults to 2 minutes.
+	RequestTimeout time.Duration
+	// Retry controls retries of failed requests, zero values are replaced by defaults.
+	Retry RetryConfig
+	// RateLimit controls client-side throttling of requests, zero values are replaced by defaults.
+	RateLimit RateLimitConfig
+	// Transport sends the requests, defaults to http.DefaultTransport. Tests use it to record interactions.
+	Transport http.RoundTripper
+}
+
+// RetryConfig describes how failed requests are retried.
+// GET, PUT and DELETE are retried on 429, 5xx and connection errors.
+// POST is retried only on 429, because a create whose response was lost could persist a duplicate.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts including the first one; 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is the base wait before the first retry, doubling with each next attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including the wait the server asks for with Retry-After.
+	MaxBackoff time.Duration
+}
+
+// withDefaults returns a copy of the config with zero values replaced by defaults.
+// MaxBackoff lower than MinBackoff is raised to it; provider configuration rejects such values.
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if r.MinBackoff <= 0 {
+		r.MinBackoff = defaultRetryMinBackoff
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = defaultRetryMaxBackoff
 	}
+	if r.MaxBackoff < r.MinBackoff {
+		r.MaxBackoff = r.MinBackoff
+	}
+	return r
+}
 
-	if credentials == "" {
-		return nil, errors.New(
-			"INDYKITE_SERVICE_ACCOUNT_CREDENTIALS or INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE must be set")
+// NewRestClient creates a new REST client for IndyKite Config API,
+// configured only from environment variables.
+func NewRestClient(ctx context.Context) (*RestClient, error) {
+	return NewRestClientWithConfig(ctx, &RestClientConfig{})
+}
+
+// NewRestClientWithConfig creates a new REST client for IndyKite Config API from the given configuration.
+// Explicit BaseURL takes precedence over Region, which takes precedence over endpoint in credentials.
+func NewRestClientWithConfig(_ context.Context, cfg *RestClientConfig) (*RestClient, error) {
+	credentials, err := resolveCredentials(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse credentials to get token and endpoint
-	token, baseURL, err := parseCredentials(credentials)
+	// Parse credentials to get token source and endpoint
+	tokens, baseURL, err := parseCredentials(credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse credentials: %w", err)
 	}
 
+	switch {
+	case cfg.BaseURL != "":
+		baseURL = normalizeBaseURL(cfg.BaseURL)
+	case cfg.Region != "":
+		baseURL = regionBaseURL(cfg.Region)
+	}
+
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	throttle := newThrottledTransport(cfg.Transport, cfg.RateLimit.withDefaults())
 	return &RestClient{
 		httpClient: &http.Client{
-			Timeout: 2 * time.Minute,
+			Timeout:   timeout,
+			Transport: throttle,
 		},
-		baseURL: baseURL,
-		token:   token,
+		throttle: throttle,
+		baseURL:  baseURL,
+		tokens:   tokens,
+		retry:    cfg.Retry.withDefaults(),
 	}, nil
 }
 
-// parseCredentials extracts token and base URL from service account credentials.
-// Returns (token, baseURL, error).
-func parseCredentials(credentials string) (string, string, error) { //nolint:revive,gocritic // different concepts
+// resolveCredentials returns credentials content from configuration,
+// falling back to environment variables when nothing is configured.
+func resolveCredentials(cfg *RestClientConfig) (string, error) {
+	credentials := cfg.Credentials
+	credsFile := cfg.CredentialsFile
+	if credentials == "" && credsFile == "" {
+		// Get service account credentials from environment
+		credentials = os.Getenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS")
+		credsFile = os.Getenv("INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE")
+	}
+
+	if credentials == "" && credsFile != "" {
+		// #nosec G304,G703 -- credsFile is from provider configuration or environment, intentional file read
+		data, err := os.ReadFile(credsFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		credentials = string(data)
+	}
+
+	if credentials == "" {
+		return "", errors.New("credentials or credentials_file must be set in provider configuration, " +
+			"or INDYKITE_SERVICE_ACCOUNT_CREDENTIALS or INDYKITE_SERVICE_ACCOUNT_CREDENTIALS_FILE must be set")
+	}
+	return credentials, nil
+}
+
+// normalizeBaseURL ensures baseURL ends with /configs/v1.
+func normalizeBaseURL(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/configs/v1") {
+		return baseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/configs/v1"
+}
+
+// regionBaseURL returns Config API base URL of the given region, e.g. "us" -> "https://us.api.indykite.com/configs/v1".
+func regionBaseURL(region string) string {
+	return "https://" + strings.ToLower(region) + ".api.indykite.com/configs/v1"
+}
+
+// parseCredentials extracts token source and base URL from service account credentials.
+// Returns (tokenSource, baseURL, error).
+func parseCredentials(credentials string) (tokenSource, string, error) {
 	var creds struct {
 		AppSpaceID       string          `json:"appSpaceId"`
 		ServiceAccountID string          `json:"serviceAccountId"`
@@ -88,39 +202,35 @@ func parseCredentials(credentials string) (string, string, error) { //nolint:rev
 	}
 
 	if unmarshalErr := json.Unmarshal([]byte(credentials), &creds); unmarshalErr != nil {
-		return "", "", fmt.Errorf("failed to parse credentials JSON: %w", unmarshalErr)
+		return nil, "", fmt.Errorf("failed to parse credentials JSON: %w", unmarshalErr)
 	}
 
 	// Determine base URL - use baseUrl from credentials if available, otherwise derive from endpoint
 	var baseURL string
 	switch {
 	case creds.BaseURL != "":
-		baseURL = creds.BaseURL
-		// Ensure baseURL ends with /configs/v1 if it doesn't already
-		if !strings.HasSuffix(baseURL, "/configs/v1") {
-			baseURL = strings.TrimSuffix(baseURL, "/") + "/configs/v1"
-		}
+		baseURL = normalizeBaseURL(creds.BaseURL)
 	case creds.Endpoint != "":
 		// Fallback: derive from endpoint for backward compatibility
 		if strings.Contains(creds.Endpoint, "us.api.indykite.com") {
-			baseURL = "https://us.api.indykite.com/configs/v1"
+			baseURL = regionBaseURL("us")
 		} else {
-			baseURL = "https://eu.api.indykite.com/configs/v1"
+			baseURL = regionBaseURL(defaultRegion)
 		}
 	default:
 		// Default to EU if neither baseUrl nor endpoint is provided
-		baseURL = "https://eu.api.indykite.com/configs/v1"
+		baseURL = regionBaseURL(defaultRegion)
 	}
 
 	// If token is provided in credentials, use it directly
 	if creds.Token != "" {
-		return creds.Token, baseURL, nil
+		return staticToken(creds.Token), baseURL, nil
 	}
 
 	// Otherwise, generate JWT token from private key (backward compatibility)
 	privateKey, kid, err := parseJWK(creds.PrivateKey)
 	if err != nil {
-		return "", baseURL, fmt.Errorf("failed to parse private key JWK: %w", err)
+		return nil, baseURL, fmt.Errorf("failed to parse private key JWK: %w", err)
 	}
 
 	// Determine the subject for JWT - use serviceAccountId if available, otherwise appSpaceId
@@ -129,13 +239,13 @@ func parseCredentials(credentials string) (string, string, error) { //nolint:rev
 		subject = creds.AppSpaceID
 	}
 
-	// Generate JWT token
-	token, err := generateJWT(privateKey, kid, subject)
-	if err != nil {
-		return "", baseURL, fmt.Errorf("failed to generate JWT token: %w", err)
+	// Sign the first token right away, so invalid keys are reported during provider configuration
+	signer := &jwtSigner{privateKey: privateKey, kid: kid, subject: subject}
+	if _, err = signer.Token(false); err != nil {
+		return nil, baseURL, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
 
-	return token, baseURL, nil
+	return signer, baseURL, nil
 }
 
 // parseJWK parses a JWK (JSON Web Key) using lestrrat-go/jwx library and returns an ECDSA private key and kid.
@@ -164,15 +274,75 @@ func parseJWK(jwkData json.RawMessage) (*ecdsa.PrivateKey, string, error) {
 	return privateKey, kid, nil
 }
 
+// The lifetime of self-signed JWT and how long before its expiration it is re-signed.
+// These are vars (not consts) so tests can shorten them via the seam in export_test.go.
+var (
+	jwtLifetime      = 1 * time.Hour
+	jwtRefreshMargin = 5 * time.Minute
+)
+
+// tokenSource provides the bearer token sent with every request.
+type tokenSource interface {
+	// Token returns a valid token. When forceRefresh is true, a new token is issued if possible.
+	Token(forceRefresh bool) (string, error)
+	// Refreshable reports whether a new token can be issued, e.g. after 401 response.
+	Refreshable() bool
+}
+
+// staticToken is a token provided directly in credentials, which cannot be renewed.
+type staticToken string
+
+func (t staticToken) Token(bool) (string, error) {
+	return string(t), nil
+}
+
+func (staticToken) Refreshable() bool {
+	return false
+}
+
+// jwtSigner keeps the service account private key and re-signs the JWT before it expires.
+// It is safe for concurrent use, as Terraform runs resource operations in parallel.
+type jwtSigner struct {
+	expiresAt  time.Time
+	privateKey *ecdsa.PrivateKey
+	kid        string
+	subject    string
+	token      string
+	mu         sync.Mutex
+}
+
+func (s *jwtSigner) Token(forceRefresh bool) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if !forceRefresh && s.token != "" && now.Before(s.expiresAt.Add(-jwtRefreshMargin)) {
+		return s.token, nil
+	}
+
+	token, err := generateJWT(s.privateKey, s.kid, s.subject, now, jwtLifetime)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiresAt = now.Add(jwtLifetime)
+	return s.token, nil
+}
+
+func (*jwtSigner) Refreshable() bool {
+	return true
+}
+
 // generateJWT creates a signed JWT token for authentication.
 // Claims match the SDK implementation (no audience claim).
-func generateJWT(privateKey *ecdsa.PrivateKey, kid, subject string) (string, error) {
-	now := time.Now()
+func generateJWT(privateKey *ecdsa.PrivateKey, kid, subject string, now time.Time, lifetime time.Duration) (
+	string, error,
+) {
 	claims := jwt.MapClaims{
 		"iss": subject,
 		"sub": subject,
 		"iat": now.Unix(),
-		"exp": now.Add(1 * time.Hour).Unix(),
+		"exp": now.Add(lifetime).Unix(),
 		"jti": strconv.FormatInt(now.UnixNano(), 10),
 	}
 
@@ -188,37 +358,151 @@ func generateJWT(privateKey *ecdsa.PrivateKey, kid, subject string) (string, err
 	return tokenString, nil
 }
 
-// Do executes an HTTP request.
+// setHeaders sets the common JSON and authorization headers.
+func (c *RestClient) setHeaders(header http.Header, forceRefresh bool) error {
+	token, err := c.tokens.Token(forceRefresh)
+	if err != nil {
+		return fmt.Errorf("failed to get authorization token: %w", err)
+	}
+	header.Set("Content-Type", "application/json")
+	header.Set("Accept", "application/json")
+	header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// retryUnauthorized reports whether a request rejected with resp should be sent once more with a new token.
+func (c *RestClient) retryUnauthorized(resp *http.Response, attempt int) bool {
+	return attempt == 0 && resp.StatusCode == http.StatusUnauthorized && c.tokens.Refreshable()
+}
+
+// Do executes an HTTP request, retrying transient failures according to the retry policy.
+// When a self-signed token is rejected with 401, the request is sent once more with a freshly signed token.
 func (c *RestClient) Do(ctx context.Context, method, path string, body, response any) (*http.Response, error) {
-	var reqBody io.Reader
+	return c.do(ctx, method, path, "", body, response)
+}
+
+// do executes an HTTP request like Do, sending etag as If-Match header when it is not empty.
+func (c *RestClient) do(ctx context.Context, method, path, etag string, body, response any) (*http.Response, error) {
+	var reqBody any
+	var rawBody []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		var err error
+		rawBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(data)
+		reqBody = rawBody
 	}
 
+	retryClient := c.newRetryClient(c.retry.MaxAttempts-1, c.retry.MinBackoff, c.retry.MaxBackoff)
+	retryClient.CheckRetry = retryPolicy(method)
+	retryClient.Backoff = jitterBackoff
+	newRequestLogger(ctx, rawBody).attach(retryClient)
+
 	url := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	for attempt := 0; ; attempt++ {
+		req, err := retryablehttp.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if err = c.setHeaders(req.Header, attempt > 0); err != nil {
+			return nil, err
+		}
+		if etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+
+		// Execute request
+		resp, err := retryClient.Do(req)
+		if err != nil {
+			if resp != nil {
+				resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+			}
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		if c.retryUnauthorized(resp, attempt) {
+			resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+			continue
+		}
+
+		err = decodeResponse(resp, response)
+		resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+		return resp, err
+	}
+}
+
+// newRetryClient returns go-retryablehttp client sharing the HTTP client of c.
+// The last response is handed back instead of a "giving up after N attempts" error,
+// so exhausted retries keep flowing through decodeResponse as a RestError.
+func (c *RestClient) newRetryClient(retryMax int, waitMin, waitMax time.Duration) *retryablehttp.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = c.httpClient
+	retryClient.RetryMax = retryMax
+	retryClient.RetryWaitMin = waitMin
+	retryClient.RetryWaitMax = waitMax
+	retryClient.Logger = nil
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// Re-read the token before each retry, long backoffs may cross its expiration.
+	retryClient.PrepareRetry = func(req *http.Request) error {
+		return c.setHeaders(req.Header, false)
 	}
+	return retryClient
+}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.token)
+// retryPolicy returns the retry check for given HTTP method. Idempotent methods are retried
+// on connection errors, 429 and 5xx (except 501). POST is retried only on 429, which guarantees
+// nothing was created; retrying a create after 5xx or a lost response could persist a duplicate.
+func retryPolicy(method string) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if method != http.MethodPost {
+			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+		}
+		return err == nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests, nil
+	}
+}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// jitterBackoff honours Retry-After header of 429 and 503 responses up to waitMax, so a single
+// response cannot stall the call until its deadline. Otherwise it waits a random
+// duration between waitMin and exponentially growing ceiling capped at waitMax ("full jitter"),
+// so parallel Terraform operations do not retry in lockstep.
+func jitterBackoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, waitMax)
+		}
+	}
+
+	ceiling := waitMax
+	if attemptNum < 32 {
+		if exp := waitMin << attemptNum; exp > 0 && exp < waitMax {
+			ceiling = exp
+		}
 	}
-	// Always close the body when we're done
-	defer resp.Body.Close() //nolint:errcheck // deferred Body.Close() error is acceptable
+	if ceiling <= waitMin {
+		return waitMin
+	}
+	return waitMin + rand.N(ceiling-waitMin) //nolint:gosec // jitter does not need cryptographic randomness
+}
 
-	return resp, decodeResponse(resp, response)
+// parseRetryAfter parses Retry-After header value, given either in seconds or as HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
 }
 
 // decodeResponse converts non-2xx responses into a RestError and unmarshals
@@ -226,10 +510,7 @@ func (c *RestClient) Do(ctx context.Context, method, path string, body, response
 func decodeResponse(resp *http.Response, response any) error {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return &RestError{
-			StatusCode: resp.StatusCode,
-			Message:    string(bodyBytes),
-		}
+		return parseRestError(resp.StatusCode, resp.Header, bodyBytes)
 	}
 
 	if response != nil && resp.StatusCode != http.StatusNoContent {
@@ -265,40 +546,41 @@ func (c *RestClient) PostWithRetryOnNotFound(
 		}
 	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.HTTPClient = c.httpClient
-	retryClient.RetryMax = retryMax
-	retryClient.RetryWaitMin = waitMin
-	retryClient.RetryWaitMax = waitMax
-	retryClient.Logger = nil
+	retryClient := c.newRetryClient(retryMax, waitMin, waitMax)
 	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		if ctx.Err() != nil {
 			return false, ctx.Err()
 		}
 		return err == nil && resp != nil && resp.StatusCode == http.StatusNotFound, nil
 	}
-	// Hand the last response back instead of a "giving up after N attempts" error,
-	// so an exhausted 404 keeps flowing through decodeResponse as a RestError.
-	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	newRequestLogger(ctx, rawBody).attach(retryClient)
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, rawBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	for attempt := 0; ; attempt++ {
+		req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, rawBody)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		if err = c.setHeaders(req.Header, attempt > 0); err != nil {
+			return err
+		}
 
-	resp, err := retryClient.Do(req)
-	if err != nil {
-		if resp != nil {
+		resp, err := retryClient.Do(req)
+		if err != nil {
+			if resp != nil {
+				resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+			}
+			return fmt.Errorf("failed to execute request: %w", err)
+		}
+		// A 401 guarantees nothing was created, so it is safe to repeat the create with a new token.
+		if c.retryUnauthorized(resp, attempt) {
 			resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+			continue
 		}
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck // deferred Body.Close() error is acceptable
 
-	return decodeResponse(resp, response)
+		err = decodeResponse(resp, response)
+		resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+		return err
+	}
 }
 
 // Get executes a GET request.
@@ -325,14 +607,41 @@ func (c *RestClient) Delete(ctx context.Context, path string) error {
 	return err
 }
 
+// PutIfMatch executes a PUT request, which succeeds only if the resource still has given etag.
+// Empty etag, e.g. from a state written by older provider version, updates unconditionally.
+func (c *RestClient) PutIfMatch(ctx context.Context, path, etag string, body, response any) error {
+	_, err := c.do(ctx, http.MethodPut, path, etag, body, response) //nolint:bodyclose // body is closed in do()
+	return err
+}
+
+// DeleteIfMatch executes a DELETE request, which succeeds only if the resource still has given etag.
+// Empty etag deletes unconditionally.
+func (c *RestClient) DeleteIfMatch(ctx context.Context, path, etag string) error {
+	_, err := c.do(ctx, http.MethodDelete, path, etag, nil, nil) //nolint:bodyclose // body is closed in do()
+	return err
+}
+
 // RestError represents an error from the REST API.
+// When the response body is the Config API error envelope, it is parsed into Code, Message,
+// FieldViolations and RequestID. Otherwise Message contains the raw body.
 type RestError struct {
-	Message    string
-	StatusCode int
+	Message         string
+	Code            string
+	RequestID       string
+	FieldViolations []ProtoValidateError
+	StatusCode      int
 }
 
 func (e *RestError) Error() string {
-	return "HTTP " + strconv.Itoa(e.StatusCode) + ": " + e.Message
+	var msg strings.Builder
+	msg.WriteString("HTTP " + strconv.Itoa(e.StatusCode) + ": " + e.Message)
+	for _, v := range e.FieldViolations {
+		msg.WriteString("; " + v.Field() + ": " + v.Reason())
+	}
+	if e.RequestID != "" {
+		msg.WriteString(" (request ID: " + e.RequestID + ")")
+	}
+	return msg.String()
 }
 
 // IsNotFoundError checks if the error is a 404 Not Found error.
@@ -344,6 +653,16 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsConflictError checks if the error is a 409 Conflict or 412 Precondition Failed error,
+// which the API returns when the resource was modified since its etag was read.
+func IsConflictError(err error) bool {
+	var restErr *RestError
+	if errors.As(err, &restErr) {
+		return restErr.StatusCode == http.StatusConflict || restErr.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
 // IsServiceError checks if the error is a service error (5xx).
 func IsServiceError(err error) bool {
 	var restErr *RestError
@@ -364,6 +683,8 @@ func NewTestRestClient(baseURL string, httpClient *http.Client) *RestClient {
 	return &RestClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
-		token:      "test-token", // For testing, we use a dummy token
+		tokens:     staticToken("test-token"), // For testing, we use a dummy token
+		// Mocked failures must surface right away, tests of retries build their own client.
+		retry: RetryConfig{MaxAttempts: 1, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
 	}
 }
@@ -0,0 +1,458 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+// privateKeyCredentials generates service account credentials with a fresh EC P-256 private key JWK.
+func privateKeyCredentials(baseURL string) string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(Succeed())
+	key, err := jwk.FromRaw(privateKey)
+	Expect(err).To(Succeed())
+	Expect(key.Set(jwk.KeyIDKey, "test-kid")).To(Succeed())
+	keyJSON, err := json.Marshal(key)
+	Expect(err).To(Succeed())
+
+	creds, err := json.Marshal(map[string]any{
+		"serviceAccountId": serviceAccountID,
+		"baseUrl":          baseURL,
+		"privateKeyJWK":    json.RawMessage(keyJSON),
+	})
+	Expect(err).To(Succeed())
+	return string(creds)
+}
+
+var _ = Describe("RestClient authorization", func() {
+	var (
+		mockServer   *httptest.Server
+		mu           sync.Mutex
+		tokens       []string
+		unauthorized int
+	)
+
+	BeforeEach(func() {
+		tokens = nil
+		unauthorized = 0
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			tokens = append(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+			if unauthorized > 0 {
+				unauthorized--
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"` + sampleID + `"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	newClient := func(credentials string) *indykite.RestClient {
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			Credentials: credentials,
+		})
+		Expect(err).To(Succeed())
+		return client
+	}
+
+	It("reuses self-signed token while it is valid", func() {
+		client := newClient(privateKeyCredentials(mockServer.URL))
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(tokens).To(HaveLen(2))
+		Expect(tokens[0]).NotTo(BeEmpty())
+		Expect(tokens[1]).To(Equal(tokens[0]))
+	})
+
+	It("re-signs token before it expires", func() {
+		DeferCleanup(indykite.SetJWTLifetime(time.Minute, time.Minute))
+		client := newClient(privateKeyCredentials(mockServer.URL))
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(tokens).To(HaveLen(2))
+		Expect(tokens[1]).NotTo(Equal(tokens[0]))
+	})
+
+	It("retries once with a new token on 401", func() {
+		unauthorized = 1
+		client := newClient(privateKeyCredentials(mockServer.URL))
+		var resp indykite.ApplicationSpaceResponse
+		Expect(client.Put(context.Background(), "/projects/"+sampleID, map[string]string{}, &resp)).To(Succeed())
+		Expect(resp.ID).To(Equal(sampleID))
+		Expect(tokens).To(HaveLen(2))
+		Expect(tokens[1]).NotTo(Equal(tokens[0]))
+	})
+
+	It("retries create once with a new token on 401", func() {
+		unauthorized = 1
+		client := newClient(privateKeyCredentials(mockServer.URL))
+		Expect(client.PostWithRetryOnNotFound(context.Background(), "/projects", map[string]string{}, nil,
+			0, time.Millisecond, time.Millisecond)).To(Succeed())
+		Expect(tokens).To(HaveLen(2))
+		Expect(tokens[1]).NotTo(Equal(tokens[0]))
+	})
+
+	It("fails after second 401", func() {
+		unauthorized = 2
+		client := newClient(privateKeyCredentials(mockServer.URL))
+		err := client.Get(context.Background(), "/projects/"+sampleID, nil)
+		Expect(err).To(MatchError(ContainSubstring("HTTP 401")))
+		Expect(tokens).To(HaveLen(2))
+	})
+
+	It("sends requests through configured transport", func() {
+		var paths []string
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			Credentials: `{"token":"static-token","baseUrl":"` + mockServer.URL + `"}`,
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				paths = append(paths, req.URL.Path)
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+		})
+		Expect(err).To(Succeed())
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(paths).To(Equal([]string{"/configs/v1/projects/" + sampleID}))
+	})
+
+	It("does not retry static token on 401", func() {
+		unauthorized = 1
+		client := newClient(`{"token":"static-token","baseUrl":"` + mockServer.URL + `"}`)
+		err := client.Get(context.Background(), "/projects/"+sampleID, nil)
+		Expect(err).To(MatchError(ContainSubstring("HTTP 401")))
+		Expect(tokens).To(Equal([]string{"static-token"}))
+	})
+})
+
+var _ = Describe("RestClient retries", func() {
+	var (
+		mockServer *httptest.Server
+		mu         sync.Mutex
+		statuses   []int
+		requests   []string
+		retryAfter string
+	)
+
+	BeforeEach(func() {
+		statuses = nil
+		requests = nil
+		retryAfter = "0"
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+string(body))
+			if len(statuses) > 0 {
+				status := statuses[0]
+				statuses = statuses[1:]
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(status)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"` + sampleID + `"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	newClient := func(retry indykite.RetryConfig) *indykite.RestClient {
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			Credentials: `{"token":"test-token"}`,
+			BaseURL:     mockServer.URL,
+			Retry:       retry,
+		})
+		Expect(err).To(Succeed())
+		return client
+	}
+	fastRetry := indykite.RetryConfig{MaxAttempts: 4, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	It("retries idempotent requests on 5xx", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway}
+		var resp indykite.ApplicationSpaceResponse
+		Expect(newClient(fastRetry).Put(context.Background(), "/projects/"+sampleID,
+			map[string]string{"description": "d"}, &resp)).To(Succeed())
+		Expect(resp.ID).To(Equal(sampleID))
+		Expect(requests).To(Equal([]string{
+			`PUT {"description":"d"}`, `PUT {"description":"d"}`, `PUT {"description":"d"}`,
+		}))
+	})
+
+	It("returns the last error when attempts are exhausted", func() {
+		statuses = slices.Repeat([]int{http.StatusInternalServerError}, 5)
+		err := newClient(fastRetry).Delete(context.Background(), "/projects/"+sampleID)
+		Expect(indykite.IsServiceError(err)).To(BeTrue())
+		Expect(requests).To(HaveLen(4))
+	})
+
+	It("honours Retry-After on 429", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// Without Retry-After the client would wait a minute and hit the context deadline.
+		client := newClient(indykite.RetryConfig{MaxAttempts: 2, MinBackoff: time.Minute, MaxBackoff: time.Minute})
+		Expect(client.Get(ctx, "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("caps Retry-After at max backoff", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		retryAfter = "3600"
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		Expect(newClient(fastRetry).Get(ctx, "/projects/"+sampleID, nil)).To(Succeed())
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("does not retry create on 5xx", func() {
+		statuses = []int{http.StatusInternalServerError}
+		err := newClient(fastRetry).Post(context.Background(), "/projects", map[string]string{}, nil)
+		Expect(indykite.IsServiceError(err)).To(BeTrue())
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("retries create on 429", func() {
+		statuses = []int{http.StatusTooManyRequests}
+		Expect(newClient(fastRetry).Post(context.Background(), "/projects",
+			map[string]string{"name": "n"}, nil)).To(Succeed())
+		Expect(requests).To(Equal([]string{`POST {"name":"n"}`, `POST {"name":"n"}`}))
+	})
+
+	It("does not retry when disabled", func() {
+		statuses = []int{http.StatusServiceUnavailable}
+		err := newClient(indykite.RetryConfig{MaxAttempts: 1}).Get(context.Background(), "/projects/"+sampleID, nil)
+		Expect(indykite.IsServiceError(err)).To(BeTrue())
+		Expect(requests).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("RestClient throttling", func() {
+	var (
+		mockServer *httptest.Server
+		mu         sync.Mutex
+		statuses   []int
+		inFlight   int
+		maxSeen    int
+	)
+
+	BeforeEach(func() {
+		statuses = nil
+		inFlight, maxSeen = 0, 0
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			mu.Lock()
+			inFlight++
+			maxSeen = max(maxSeen, inFlight)
+			status := http.StatusOK
+			if len(statuses) > 0 {
+				status, statuses = statuses[0], statuses[1:]
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(status)
+		}))
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	newClient := func(rateLimit indykite.RateLimitConfig) *indykite.RestClient {
+		client, err := indykite.NewRestClientWithConfig(context.Background(), &indykite.RestClientConfig{
+			Credentials: `{"token":"test-token"}`,
+			BaseURL:     mockServer.URL,
+			Retry: indykite.RetryConfig{
+				MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond,
+			},
+			RateLimit: rateLimit,
+		})
+		Expect(err).To(Succeed())
+		return client
+	}
+
+	It("caps requests in flight", func() {
+		client := newClient(indykite.RateLimitConfig{RequestsPerSecond: 1000, Burst: 100, MaxInFlight: 2})
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				defer GinkgoRecover()
+				Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+			})
+		}
+		wg.Wait()
+		Expect(maxSeen).To(Equal(2))
+	})
+
+	It("halves the rate on 429 and restores it on success", func() {
+		client := newClient(indykite.RateLimitConfig{RequestsPerSecond: 1000, Burst: 100, MaxInFlight: 10})
+		statuses = []int{http.StatusTooManyRequests, http.StatusTooManyRequests}
+		Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		// Two 429 halve the rate twice, the final success adds 1/20 of the configured rate.
+		Expect(indykite.CurrentRateLimit(client)).To(BeNumerically("~", 300, 0.001))
+
+		for range 20 {
+			Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		}
+		Expect(indykite.CurrentRateLimit(client)).To(BeNumerically("==", 1000))
+	})
+
+	It("waits for rate tokens", func() {
+		client := newClient(indykite.RateLimitConfig{RequestsPerSecond: 20, Burst: 1, MaxInFlight: 10})
+		start := time.Now()
+		for range 3 {
+			Expect(client.Get(context.Background(), "/projects/"+sampleID, nil)).To(Succeed())
+		}
+		// First request uses the burst token, the next two wait 50ms each.
+		Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+	})
+})
+
+var _ = Describe("RestClient logging", func() {
+	var mockServer *httptest.Server
+
+	BeforeEach(func() {
+		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Request-Id", "req-123")
+			_, _ = w.Write([]byte(`{"id":"` + sampleID + `","application_agent_config":"{\"secret\":1}",` +
+				`"items":[{"connection_string":"Endpoint=sb://secret"}]}`))
+		}))
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	It("logs requests with redacted bodies", func() {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		client := indykite.NewTestRestClient(mockServer.URL, nil)
+
+		Expect(client.Post(ctx, "/event-sinks", map[string]any{
+			"name": "wonka",
+			"providers": map[string]any{"kafka": map[string]any{
+				"username": "wonka", "password": "very-secret",
+			}},
+			"accessKey": "very-secret-key",
+			"token":     "very-secret-token",
+		}, nil)).To(Succeed())
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		Expect(err).To(Succeed())
+		Expect(entries).To(ContainElements(
+			MatchKeys(IgnoreExtras, Keys{
+				"@message": Equal("Sending request"),
+				"@module":  Equal("provider.rest"),
+				"@level":   Equal("debug"),
+				"method":   Equal("POST"),
+				"path":     Equal("/event-sinks"),
+				"attempt":  BeNumerically("==", 1),
+			}),
+			MatchKeys(IgnoreExtras, Keys{
+				"@message":   Equal("Received response"),
+				"status":     BeNumerically("==", 200),
+				"request_id": Equal("req-123"),
+				"latency_ms": BeNumerically(">=", 0),
+			}),
+			MatchKeys(IgnoreExtras, Keys{
+				"@message": Equal("Request body"),
+				"@level":   Equal("trace"),
+				"body":     ContainSubstring(`"username":"wonka"`),
+			}),
+			MatchKeys(IgnoreExtras, Keys{
+				"@message": Equal("Response body"),
+				"body":     ContainSubstring(`"connection_string":"***REDACTED***"`),
+			}),
+		))
+		Expect(output.String()).NotTo(ContainSubstring("very-secret"))
+		Expect(output.String()).NotTo(ContainSubstring("sb://secret"))
+		Expect(output.String()).NotTo(ContainSubstring(`\"secret\"`))
+	})
+
+	It("redacts credentials in headers sent by the API", func() {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		client := indykite.NewTestRestClient(mockServer.URL, nil)
+
+		Expect(client.Post(ctx, "/external-data-resolvers", map[string]any{
+			"name": "wonka",
+			"headers": map[string]any{
+				"Authorization": map[string]any{"values": []string{"Bearer very-secret-bearer"}},
+				"X-API-Key":     map[string]any{"values": []string{"very-secret-api-key"}},
+				"Cookie":        map[string]any{"values": []string{"session=very-secret-cookie"}},
+				"Accept":        map[string]any{"values": []string{"application/json"}},
+			},
+			"client_secret": "very-secret-client",
+			"apiKey":        "very-secret-key",
+		}, nil)).To(Succeed())
+
+		entries, err := tflogtest.MultilineJSONDecode(&output)
+		Expect(err).To(Succeed())
+		Expect(entries).To(ContainElement(MatchKeys(IgnoreExtras, Keys{
+			"@message": Equal("Request body"),
+			"body": And(
+				ContainSubstring(`"Authorization":"***REDACTED***"`),
+				ContainSubstring(`"X-API-Key":"***REDACTED***"`),
+				ContainSubstring(`"Cookie":"***REDACTED***"`),
+				ContainSubstring(`"Accept":{"values":["application/json"]}`),
+				ContainSubstring(`"client_secret":"***REDACTED***"`),
+				ContainSubstring(`"apiKey":"***REDACTED***"`),
+			),
+		})))
+		Expect(output.String()).NotTo(ContainSubstring("very-secret"))
+	})
+})
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
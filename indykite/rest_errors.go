@@ -0,0 +1,126 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// errorEnvelope is the error body returned by Config API. Both snake_case and camelCase
+// variants are accepted, as well as the envelope nested in "error" key.
+type errorEnvelope struct {
+	Error                *errorEnvelope      `json:"error"`
+	Code                 json.RawMessage     `json:"code"`
+	Message              string              `json:"message"`
+	RequestID            string              `json:"request_id"`
+	RequestIDCamel       string              `json:"requestId"`
+	FieldViolations      []rawFieldViolation `json:"field_violations"`
+	FieldViolationsCamel []rawFieldViolation `json:"fieldViolations"`
+	Details              []struct {
+		FieldViolations      []rawFieldViolation `json:"field_violations"`
+		FieldViolationsCamel []rawFieldViolation `json:"fieldViolations"`
+	} `json:"details"`
+	// Errors is a list of plain messages or field violations.
+	Errors []json.RawMessage `json:"errors"`
+}
+
+type rawFieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Message     string `json:"message"`
+	Reason      string `json:"reason"`
+}
+
+// fieldViolation is a rejected field of the request, reported by Config API.
+type fieldViolation struct {
+	field  string
+	reason string
+}
+
+var _ ProtoValidateError = fieldViolation{}
+
+func (v fieldViolation) Field() string   { return v.field }
+func (v fieldViolation) Reason() string  { return v.reason }
+func (fieldViolation) Cause() error      { return nil }
+func (fieldViolation) Key() bool         { return false }
+func (fieldViolation) ErrorName() string { return "FieldViolation" }
+func (v fieldViolation) Error() string   { return v.field + ": " + v.reason }
+
+// parseRestError builds RestError from a non-2xx response.
+func parseRestError(statusCode int, header http.Header, body []byte) *RestError {
+	restErr := &RestError{StatusCode: statusCode, Message: string(body), RequestID: requestID(header)}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return restErr
+	}
+	if envelope.Error != nil {
+		envelope = *envelope.Error
+	}
+
+	var messages []string
+	if envelope.Message != "" {
+		messages = append(messages, envelope.Message)
+	}
+	violations := slices.Concat(envelope.FieldViolations, envelope.FieldViolationsCamel)
+	for _, detail := range envelope.Details {
+		violations = append(violations, detail.FieldViolations...)
+		violations = append(violations, detail.FieldViolationsCamel...)
+	}
+	for _, raw := range envelope.Errors {
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			messages = append(messages, text)
+			continue
+		}
+		var violation rawFieldViolation
+		if json.Unmarshal(raw, &violation) == nil {
+			violations = append(violations, violation)
+		}
+	}
+
+	for _, v := range violations {
+		reason := firstNonEmpty(v.Description, v.Message, v.Reason)
+		if v.Field == "" {
+			if reason != "" {
+				messages = append(messages, reason)
+			}
+			continue
+		}
+		restErr.FieldViolations = append(restErr.FieldViolations, fieldViolation{field: v.Field, reason: reason})
+	}
+
+	if len(messages) > 0 {
+		restErr.Message = strings.Join(messages, "; ")
+	}
+	restErr.Code = strings.Trim(string(bytes.TrimSpace(envelope.Code)), `"`)
+	if id := firstNonEmpty(envelope.RequestID, envelope.RequestIDCamel); id != "" {
+		restErr.RequestID = id
+	}
+	return restErr
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
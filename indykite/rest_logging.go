@@ -0,0 +1,245 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// restLogSubsystem is the tflog subsystem of REST client logs.
+// Its level can be set with TF_LOG_PROVIDER_INDYKITE_REST environment variable.
+const restLogSubsystem = "rest"
+
+// redactedValue replaces values of sensitive fields in logged bodies.
+const redactedValue = "***REDACTED***"
+
+// sensitiveKeys are JSON keys, whose values are secrets never logged nor saved to test cassettes.
+// Keys are compared lowercased and without underscores or hyphens, so both snake_case and camelCase
+// variants match. Keys ending with "token" are sensitive as well.
+var sensitiveKeys = map[string]bool{
+	"password":         true,
+	"accesskey":        true,
+	"connectionstring": true,
+	"credentialsjson":  true,
+	"privatekeyjwk":    true,
+	"privatekey":       true,
+	"clientsecret":     true,
+	"apikey":           true,
+}
+
+// credentialConfigKeys are JSON keys of credential configurations, which contain private keys.
+// They are sensitive as a whole, normalized as sensitiveKeys.
+var credentialConfigKeys = map[string]bool{
+	"agentconfig":            true,
+	"applicationagentconfig": true,
+	"serviceaccountconfig":   true,
+}
+
+// headersLogKey is JSON key of HTTP headers the API sends on behalf of the user, like headers
+// of external data resolvers. Values of headers with credentials are never logged.
+const headersLogKey = "headers"
+
+// sensitiveHeaderNames are HTTP header names carrying credentials, normalized as sensitiveKeys.
+// Names ending with "apikey", like X-API-Key, and names of sensitive keys are sensitive as well.
+var sensitiveHeaderNames = map[string]bool{
+	"authorization":      true,
+	"proxyauthorization": true,
+	"cookie":             true,
+	"setcookie":          true,
+}
+
+// jwtPattern matches JWT in any logged text, e.g. in plain text error messages.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// requestLogger emits tflog logs of every attempt of a single REST call.
+// It implements retryablehttp.LeveledLogger, so retry decisions are logged too.
+type requestLogger struct {
+	ctx     context.Context //nolint:containedctx // logger is bound to the context of a single call
+	start   time.Time
+	body    []byte
+	attempt int
+}
+
+func newRequestLogger(ctx context.Context, body []byte) *requestLogger {
+	ctx = tflog.NewSubsystem(ctx, restLogSubsystem)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, restLogSubsystem, jwtPattern)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, restLogSubsystem, jwtPattern)
+	return &requestLogger{ctx: ctx, body: body}
+}
+
+// attach sets the logger and its hooks on the retry client.
+func (l *requestLogger) attach(client *retryablehttp.Client) {
+	client.Logger = l
+	client.RequestLogHook = l.logRequest
+	client.ResponseLogHook = l.logResponse
+}
+
+func (l *requestLogger) logRequest(_ retryablehttp.Logger, req *http.Request, _ int) {
+	l.attempt++
+	l.start = time.Now()
+	fields := map[string]any{
+		"method":  req.Method,
+		"path":    req.URL.Path,
+		"attempt": l.attempt,
+	}
+	tflog.SubsystemDebug(l.ctx, restLogSubsystem, "Sending request", fields)
+	if len(l.body) > 0 {
+		fields["body"] = redactBody(l.body)
+		tflog.SubsystemTrace(l.ctx, restLogSubsystem, "Request body", fields)
+	}
+}
+
+func (l *requestLogger) logResponse(_ retryablehttp.Logger, resp *http.Response) {
+	fields := map[string]any{
+		"method":      resp.Request.Method,
+		"path":        resp.Request.URL.Path,
+		"status":      resp.StatusCode,
+		"latency_ms":  time.Since(l.start).Milliseconds(),
+		"attempt":     l.attempt,
+		"request_id":  requestID(resp.Header),
+		"retry_after": resp.Header.Get("Retry-After"),
+	}
+	if fields["retry_after"] == "" {
+		delete(fields, "retry_after")
+	}
+	tflog.SubsystemDebug(l.ctx, restLogSubsystem, "Received response", fields)
+
+	// Buffer the body, so it can be logged and still decoded by the caller.
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck,gosec // Body.Close() error is acceptable
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err == nil && len(body) > 0 {
+		fields["body"] = redactBody(body)
+		tflog.SubsystemTrace(l.ctx, restLogSubsystem, "Response body", fields)
+	}
+}
+
+// Error, Info, Debug and Warn implement retryablehttp.LeveledLogger.
+func (l *requestLogger) Error(msg string, keysAndValues ...any) {
+	tflog.SubsystemError(l.ctx, restLogSubsystem, msg, l.fields(keysAndValues))
+}
+
+func (l *requestLogger) Info(msg string, keysAndValues ...any) {
+	tflog.SubsystemInfo(l.ctx, restLogSubsystem, msg, l.fields(keysAndValues))
+}
+
+func (l *requestLogger) Debug(msg string, keysAndValues ...any) {
+	tflog.SubsystemDebug(l.ctx, restLogSubsystem, msg, l.fields(keysAndValues))
+}
+
+func (l *requestLogger) Warn(msg string, keysAndValues ...any) {
+	tflog.SubsystemWarn(l.ctx, restLogSubsystem, msg, l.fields(keysAndValues))
+}
+
+func (l *requestLogger) fields(keysAndValues []any) map[string]any {
+	fields := map[string]any{"attempt": l.attempt}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if key, ok := keysAndValues[i].(string); ok {
+			fields[key] = keysAndValues[i+1]
+		}
+	}
+	return fields
+}
+
+// requestID returns the request ID the API assigned to the response, if any.
+func requestID(header http.Header) string {
+	for _, name := range []string{"X-Request-Id", "Request-Id"} {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// redactBody returns the JSON body with values of sensitive fields replaced. Bodies, which are not
+// JSON, are returned as they are; JWTs in them are masked by the logger.
+func redactBody(body []byte) string {
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactValue(parsed))
+	if err != nil {
+		return redactedValue
+	}
+	return string(redacted)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			headers, isMap := val.(map[string]any)
+			switch {
+			case IsSensitiveKey(key):
+				v[key] = redactedValue
+			case isMap && normalizeKey(key) == headersLogKey:
+				redactHeaders(headers)
+			default:
+				v[key] = redactValue(val)
+			}
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return value
+}
+
+// redactHeaders replaces whole values of headers with credentials, like {"values":["Bearer ..."]}.
+func redactHeaders(headers map[string]any) {
+	for name, val := range headers {
+		if IsSensitiveHeader(name) {
+			headers[name] = redactedValue
+			continue
+		}
+		headers[name] = redactValue(val)
+	}
+}
+
+// IsSensitiveKey reports whether value of JSON key is a secret, including credential configurations.
+// Logs redact such values, and test cassettes scrub them.
+func IsSensitiveKey(key string) bool {
+	normalized := normalizeKey(key)
+	return sensitiveKeys[normalized] || credentialConfigKeys[normalized] || strings.HasSuffix(normalized, "token")
+}
+
+// IsCredentialConfigKey reports whether JSON key holds credential configuration,
+// which is a JSON object, or JSON object encoded in a string, containing private keys.
+func IsCredentialConfigKey(key string) bool {
+	return credentialConfigKeys[normalizeKey(key)]
+}
+
+// IsSensitiveHeader reports whether HTTP header carries credentials, like Authorization or X-API-Key.
+func IsSensitiveHeader(name string) bool {
+	normalized := normalizeKey(name)
+	return sensitiveHeaderNames[normalized] || strings.HasSuffix(normalized, "apikey") || IsSensitiveKey(name)
+}
+
+// normalizeKey lowercases the key and removes separators, so "API-Key", "api_key" and "apiKey" match.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
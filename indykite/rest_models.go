@@ -22,8 +22,10 @@ import (
 // Common structures
 
 // ListResponse is the generic wrapper returned by all Config API list endpoints.
+// NextPageToken is set when more entries are available; pass it back as page_token query parameter.
 type ListResponse[T any] struct {
-	Data []T `json:"data"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	Data          []T    `json:"data"`
 }
 
 // BaseResponse contains common fields in all responses.
@@ -16,12 +16,17 @@ package indykite
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -142,6 +147,35 @@ func ValidateYaml(i any, path cty.Path) diag.Diagnostics {
 	return nil
 }
 
+// ValidateDuration is Terraform validation helper to verify value is valid positive Go duration, like "90s".
+func ValidateDuration(i any, path cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{buildPluginErrorWithPath(
+			fmt.Sprintf("validateDuration failed, expected string, got %T", i),
+			path,
+		)}
+	}
+	dur, err := time.ParseDuration(v)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	if dur <= 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        "expected positive duration, got " + v,
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
 // DisplayNameDiffSuppress suppress Terraform changes when it contains name returned from API.
 func DisplayNameDiffSuppress(k, old, newVal string, d *schema.ResourceData) bool {
 	if k == displayNameKey && old == d.Get(nameKey).(string) && newVal == "" {
@@ -289,6 +323,35 @@ func HasFailed(d *diag.Diagnostics, err error) bool {
 			Detail:   err.Error(),
 		})
 
+	case hasRestStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity):
+		*d = append(*d, buildValidationDiagnostics(err)...)
+
+	case hasRestStatus(err, http.StatusUnauthorized):
+		*d = append(*d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Authentication to IndyKite failed",
+			Detail: "Check that the service account credentials are valid and not expired. " +
+				restErrorDetail(err),
+		})
+
+	case hasRestStatus(err, http.StatusForbidden):
+		*d = append(*d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Permission denied by IndyKite",
+			Detail: "The service account is not allowed to perform this operation, " +
+				"check its role and the location of the resource. " + restErrorDetail(err),
+		})
+
+	case IsConflictError(err):
+		*d = append(*d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Resource was modified outside of Terraform, please refresh",
+			Detail: "The resource changed since Terraform last read it, for example by an edit in The Hub. " +
+				"Run 'terraform apply -refresh-only' or 'terraform plan' to review the remote changes " +
+				"and apply again. " +
+				err.Error(),
+		})
+
 	default:
 		*d = append(*d, buildPluginError(err.Error()))
 	}
@@ -296,6 +359,88 @@ func HasFailed(d *diag.Diagnostics, err error) bool {
 	return true
 }
 
+// hasRestStatus reports whether err is RestError with one of given status codes.
+func hasRestStatus(err error, statusCodes ...int) bool {
+	var restErr *RestError
+	return errors.As(err, &restErr) && slices.Contains(statusCodes, restErr.StatusCode)
+}
+
+// restErrorDetail returns error message extended with API error code, if there is any.
+func restErrorDetail(err error) string {
+	var restErr *RestError
+	if errors.As(err, &restErr) && restErr.Code != "" {
+		return err.Error() + " [code: " + restErr.Code + "]"
+	}
+	return err.Error()
+}
+
+// buildValidationDiagnostics converts request rejected by the API into user errors.
+// Each field violation gets its own diagnostic pointing to the attribute, so Terraform can show
+// the offending line of configuration.
+func buildValidationDiagnostics(err error) diag.Diagnostics {
+	var restErr *RestError
+	if !errors.As(err, &restErr) || len(restErr.FieldViolations) == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Request rejected by IndyKite as invalid",
+			Detail:   restErrorDetail(err),
+		}}
+	}
+
+	d := make(diag.Diagnostics, 0, len(restErr.FieldViolations))
+	for _, violation := range restErr.FieldViolations {
+		d = append(d, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid value of " + violation.Field() + ": " + violation.Reason(),
+			Detail:        restErrorDetail(err),
+			AttributePath: fieldViolationPath(violation.Field()),
+		})
+	}
+	return d
+}
+
+// fieldViolationPath converts field path from the API, like "config.brokers[0]" or "displayName",
+// into attribute path. API names of location are mapped to location attribute.
+func fieldViolationPath(field string) cty.Path {
+	var path cty.Path
+	for i, part := range strings.Split(field, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		name = camelToSnake(name)
+		if i == 0 && (name == "project_id" || name == "organization_id" || name == "parent_id") {
+			name = locationKey
+		}
+		if name != "" {
+			path = path.GetAttr(name)
+		}
+		for rest != "" {
+			var index string
+			index, rest, _ = strings.Cut(rest, "]")
+			rest = strings.TrimPrefix(rest, "[")
+			if n, err := strconv.ParseInt(index, 10, 64); err == nil {
+				path = path.IndexInt(int(n))
+			} else {
+				path = path.IndexString(strings.Trim(index, `"'`))
+			}
+		}
+	}
+	return path
+}
+
+// camelToSnake converts camelCase name to snake_case, snake_case names are returned unchanged.
+func camelToSnake(name string) string {
+	var out strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				out.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
 func readHasFailed(d *diag.Diagnostics, err error, data *schema.ResourceData) bool {
 	if HasFailed(d, err) {
 		if IsNotFoundError(err) {
@@ -26,6 +26,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Utilities", func() {
@@ -56,6 +57,28 @@ var _ = Describe("Utilities", func() {
 		Entry("Valid Raw URL Base64", "gid:SGVsbG_CsEluZHlLaXRlIQ", nil),
 	)
 
+	DescribeTable("ValidateDuration",
+		func(input any, errStringMatcher OmegaMatcher) {
+			path := cty.GetAttrPath("request_timeout")
+			d := indykite.ValidateDuration(input, path)
+
+			if errStringMatcher == nil {
+				Expect(d).To(BeEmpty())
+			} else {
+				Expect(d).To(HaveLen(1))
+				Expect(d[0].Detail).To(errStringMatcher)
+				Expect(d[0].AttributePath).To(Equal(path))
+			}
+		},
+		Entry("Not a string", 22, ContainSubstring("plugin error")),
+		Entry("Not a duration", "abc", ContainSubstring(`invalid duration "abc"`)),
+		Entry("Missing unit", "90", ContainSubstring("missing unit")),
+		Entry("Zero", "0s", Equal("expected positive duration, got 0s")),
+		Entry("Negative", "-1m", Equal("expected positive duration, got -1m")),
+		Entry("Seconds", "90s", nil),
+		Entry("Combined", "1h30m", nil),
+	)
+
 	DescribeTable("DisplayNameDiffSuppress",
 		func(k, currentName, old, newVal string, expected OmegaMatcher) {
 			resourceData := schema.TestResourceDataRaw(GinkgoT(),
@@ -128,6 +151,70 @@ var _ = Describe("HasFailed", func() {
 		})
 	})
 
+	Context("when the request is rejected as invalid", func() {
+		It("should point each field violation to its attribute", func() {
+			err := indykite.ParseRestError(http.StatusBadRequest, nil, []byte(`{
+				"code": "INVALID_ARGUMENT",
+				"message": "validation failed",
+				"request_id": "req-1",
+				"details": [{"field_violations": [
+					{"field": "displayName", "description": "must be at most 254 characters"},
+					{"field": "config.brokers[1]", "description": "must be host:port"}
+				]}]
+			}`))
+			Expect(err.Error()).To(Equal("HTTP 400: validation failed; displayName: must be at most 254 characters; " +
+				"config.brokers[1]: must be host:port (request ID: req-1)"))
+
+			Expect(indykite.HasFailed(diagnostics, err)).To(BeTrue())
+			Expect(*diagnostics).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Severity":      Equal(diag.Error),
+					"Summary":       Equal("Invalid value of displayName: must be at most 254 characters"),
+					"Detail":        HaveSuffix("(request ID: req-1) [code: INVALID_ARGUMENT]"),
+					"AttributePath": Equal(cty.GetAttrPath("display_name")),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Summary":       Equal("Invalid value of config.brokers[1]: must be host:port"),
+					"AttributePath": Equal(cty.GetAttrPath("config").GetAttr("brokers").IndexInt(1)),
+				}),
+			))
+		})
+
+		It("should report plain messages as user error", func() {
+			err := indykite.ParseRestError(http.StatusUnprocessableEntity, http.Header{"X-Request-Id": {"req-2"}},
+				[]byte(`{"message": "Unprocessable Entity", "errors": ["missing field policy"]}`))
+			Expect(err.Message).To(Equal("Unprocessable Entity; missing field policy"))
+			Expect(err.RequestID).To(Equal("req-2"))
+
+			Expect(indykite.HasFailed(diagnostics, err)).To(BeTrue())
+			Expect(*diagnostics).To(HaveExactElements(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Error),
+				"Summary":  Equal("Request rejected by IndyKite as invalid"),
+				"Detail":   Equal("HTTP 422: Unprocessable Entity; missing field policy (request ID: req-2)"),
+			})))
+		})
+
+		It("should keep body, which is not the error envelope", func() {
+			err := indykite.ParseRestError(http.StatusBadRequest, nil, []byte("bad request"))
+			Expect(err.Error()).To(Equal("HTTP 400: bad request"))
+			Expect(err.FieldViolations).To(BeEmpty())
+		})
+	})
+
+	Context("when the request is not permitted", func() {
+		It("should report permission error", func() {
+			err := indykite.ParseRestError(http.StatusForbidden, nil,
+				[]byte(`{"error": {"code": 7, "message": "permission denied"}}`))
+			Expect(err.Code).To(Equal("7"))
+			Expect(indykite.HasFailed(diagnostics, err)).To(BeTrue())
+			Expect(*diagnostics).To(HaveExactElements(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Error),
+				"Summary":  Equal("Permission denied by IndyKite"),
+				"Detail":   HaveSuffix("HTTP 403: permission denied [code: 7]"),
+			})))
+		})
+	})
+
 	Context("when the error is of another type", func() {
 		It("should log a generic error and return true", func() {
 			err := errors.New("generic error")
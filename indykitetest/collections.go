@@ -0,0 +1,149 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykitetest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Collections served by the fake, named by their path under the Config API base URL.
+const (
+	Projects                    = "projects"
+	Applications                = "applications"
+	ApplicationAgents           = "application-agents"
+	ApplicationAgentCredentials = "application-agent-credentials"
+	ServiceAccounts             = "service-accounts"
+	ServiceAccountCredentials   = "service-account-credentials"
+	AuthorizationPolicies       = "authorization-policies"
+	EntityMatchingPipelines     = "entity-matching-pipelines"
+	EventSinks                  = "event-sinks"
+	ExternalDataResolvers       = "external-data-resolvers"
+	KnowledgeQueries            = "knowledge-queries"
+	MCPServers                  = "mcp-servers"
+	TokenIntrospects            = "token-introspects"
+	TrustScoreProfiles          = "trust-score-profiles"
+)
+
+const (
+	organizationIDKey = "organization_id"
+	projectIDKey      = "project_id"
+	applicationIDKey  = "application_id"
+	locationParam     = "location"
+)
+
+// scopeKeys are the fields copied from the parent object, so every object knows all its ancestors.
+var scopeKeys = []string{organizationIDKey, projectIDKey, applicationIDKey}
+
+// collection describes how objects of one Config API collection are created.
+type collection struct {
+	// parentKey is the field of the create request holding the parent GID.
+	parentKey string
+	// parent is the collection of the parent, empty when the parent is the organization.
+	parent string
+	// create sets server generated fields of the new object and returns fields,
+	// which are part of the create response only, like credential configuration.
+	create func(obj map[string]any) (map[string]any, error)
+}
+
+func projectScoped() collection {
+	return collection{parentKey: projectIDKey, parent: Projects}
+}
+
+var collections = map[string]collection{
+	Projects: {parentKey: organizationIDKey, create: func(obj map[string]any) (map[string]any, error) {
+		// IKG provisioning is instant in the fake
+		obj["ikg_status"] = "APP_SPACE_IKG_STATUS_STATUS_ACTIVE"
+		return nil, nil
+	}},
+	Applications:      projectScoped(),
+	ApplicationAgents: {parentKey: applicationIDKey, parent: Applications},
+	ApplicationAgentCredentials: {
+		parentKey: "application_agent_id",
+		parent:    ApplicationAgents,
+		create:    credentialCreator("application_agent_config", "appAgentId", "application_agent_id", true),
+	},
+	ServiceAccounts: {parentKey: organizationIDKey},
+	ServiceAccountCredentials: {
+		parentKey: "service_account_id",
+		parent:    ServiceAccounts,
+		create:    credentialCreator("service_account_config", "serviceAccountId", "service_account_id", false),
+	},
+	AuthorizationPolicies:   projectScoped(),
+	EntityMatchingPipelines: projectScoped(),
+	EventSinks:              projectScoped(),
+	ExternalDataResolvers:   projectScoped(),
+	KnowledgeQueries:        projectScoped(),
+	MCPServers:              projectScoped(),
+	TokenIntrospects:        projectScoped(),
+	TrustScoreProfiles:      projectScoped(),
+}
+
+// credentialCreator returns create hook of credentials, which assigns kid and returns configuration
+// with a freshly generated private key. When the request carries a public key, the private key stays
+// with the caller and the configuration holds only the kid. Agent configuration is a JSON object,
+// while service account configuration is a JSON string.
+func credentialCreator(
+	configKey, ownerField, ownerKey string,
+	rawConfig bool,
+) func(map[string]any) (map[string]any, error) {
+	return func(obj map[string]any) (map[string]any, error) {
+		config := map[string]any{ownerField: obj[ownerKey]}
+		var kid string
+		if obj["public_key_jwk"] != nil || obj["public_key_pem"] != nil {
+			sum := sha256.Sum256([]byte(obj["id"].(string)))
+			kid = base64.RawURLEncoding.EncodeToString(sum[:])
+		} else {
+			privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				return nil, err
+			}
+			key, err := jwk.FromRaw(privateKey)
+			if err != nil {
+				return nil, err
+			}
+			thumbprint, err := key.Thumbprint(crypto.SHA256)
+			if err != nil {
+				return nil, err
+			}
+			kid = base64.RawURLEncoding.EncodeToString(thumbprint)
+			if err = key.Set(jwk.KeyIDKey, kid); err != nil {
+				return nil, err
+			}
+			config["privateKeyJWK"] = key
+		}
+		obj["kid"] = kid
+		// Public keys are not returned back
+		delete(obj, "public_key_jwk")
+		delete(obj, "public_key_pem")
+		config["kid"] = kid
+
+		raw, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		if rawConfig {
+			return map[string]any{configKey: json.RawMessage(raw)}, nil
+		}
+		return map[string]any{configKey: string(raw)}, nil
+	}
+}
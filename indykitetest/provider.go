@@ -0,0 +1,61 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykitetest
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+)
+
+// Client returns REST client, which sends all requests to the server.
+func (s *Server) Client() *indykite.RestClient {
+	return indykite.NewTestRestClient(s.URL(), s.HTTPClient())
+}
+
+// Provider returns the IndyKite provider, which sends all requests to the server.
+// No credentials are needed in the provider configuration.
+func (s *Server) Provider() *schema.Provider {
+	provider := indykite.Provider()
+	cfgFunc := provider.ConfigureContextFunc
+	provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+		return cfgFunc(indykite.WithClient(ctx, s.Client()), data)
+	}
+	return provider
+}
+
+// ProtoV6ProviderFactories returns provider factories for resource.TestCase,
+// serving both SDK and framework resources backed by the server.
+func (s *Server) ProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return ProtoV6ProviderFactories(s.Provider())
+}
+
+// ProtoV6ProviderFactories returns provider factories for resource.TestCase,
+// serving the provider muxed with framework resources, as main does.
+func ProtoV6ProviderFactories(provider *schema.Provider) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"indykite": func() (tfprotov6.ProviderServer, error) {
+			factory, err := indykite.ProviderServerFactory(context.Background(), provider)
+			if err != nil {
+				return nil, err
+			}
+			return factory(), nil
+		},
+	}
+}
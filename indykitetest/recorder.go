@@ -0,0 +1,354 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykitetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+)
+
+// CassetteModeEnv is the environment variable selecting the Mode returned by ModeFromEnv.
+const CassetteModeEnv = "INDYKITE_TEST_CASSETTE_MODE"
+
+// Mode of the Recorder.
+type Mode string
+
+const (
+	// ModeLive sends requests to the API without recording anything.
+	ModeLive Mode = "live"
+	// ModeRecord sends requests to the API and saves scrubbed interactions to the cassette.
+	ModeRecord Mode = "record"
+	// ModeReplay serves interactions from the cassette without any network access.
+	ModeReplay Mode = "replay"
+)
+
+// replayBaseURL is the base URL of clients in replay mode, requests never leave the process.
+const replayBaseURL = "https://replay.indykite.invalid" + BasePath
+
+// scrubbedValue replaces values of secrets in cassettes.
+const scrubbedValue = "***SCRUBBED***"
+
+// headersKey is JSON key of HTTP headers the API sends on behalf of the user, like headers of external
+// data resolvers. Values of headers with credentials are scrubbed.
+const headersKey = "headers"
+
+// jwtPattern matches JWT in any saved text.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// savedHeaders are the only headers saved to cassettes, credentials are sent in other headers.
+var savedHeaders = []string{"Content-Type", "If-Match", "Retry-After"}
+
+// Cassette is the file format of recorded interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+	used     bool
+}
+
+// RecordedRequest is the scrubbed request. Replay matches requests by method and URI only,
+// the body is kept to show changes of the API contract in fixture diffs.
+type RecordedRequest struct {
+	Header http.Header     `json:"header,omitempty"`
+	Method string          `json:"method"`
+	URI    string          `json:"uri"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response.
+type RecordedResponse struct {
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Status int             `json:"status"`
+}
+
+// Recorder is http.RoundTripper, which records interactions with Config API into a cassette file,
+// or replays them from it. Call Stop when done, so the cassette is saved.
+type Recorder struct {
+	transport http.RoundTripper
+	cassette  *Cassette
+	path      string
+	mode      Mode
+	mutex     sync.Mutex
+}
+
+// ModeFromEnv returns the mode set in CassetteModeEnv environment variable, ModeLive when it is empty.
+func ModeFromEnv() (Mode, error) {
+	switch mode := Mode(os.Getenv(CassetteModeEnv)); mode {
+	case "":
+		return ModeLive, nil
+	case ModeLive, ModeRecord, ModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q, expected one of %s, %s or %s",
+			CassetteModeEnv, mode, ModeLive, ModeRecord, ModeReplay)
+	}
+}
+
+// NewRecorder returns recorder of the cassette at path. Transport sends requests in live and record mode,
+// and defaults to http.DefaultTransport. In replay mode, the cassette must exist.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{transport: transport, path: path, mode: mode, cassette: &Cassette{}}
+	if mode != ModeReplay {
+		return r, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- cassette path is given by the test
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err = json.Unmarshal(data, r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RestClient returns REST client sending requests through the recorder. In live and record mode,
+// it is configured from environment variables like the provider. In replay mode, no credentials are needed.
+func (r *Recorder) RestClient(ctx context.Context) (*indykite.RestClient, error) {
+	if r.mode == ModeReplay {
+		return indykite.NewTestRestClient(replayBaseURL, &http.Client{Transport: r}), nil
+	}
+	return indykite.NewRestClientWithConfig(ctx, &indykite.RestClientConfig{Transport: r})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	if r.mode != ModeRecord {
+		return r.transport.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: filterHeader(req.Header),
+			Body:   scrubBody(reqBody),
+		},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: filterHeader(resp.Header),
+			Body:   scrubBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns response of the first unused interaction with the same method and URI.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.used || interaction.Request.Method != req.Method ||
+			interaction.Request.URI != req.URL.RequestURI() {
+			continue
+		}
+		interaction.used = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		status := interaction.Response.Status
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s", r.path, req.Method, req.URL.RequestURI())
+}
+
+// Stop saves the cassette in record mode. In replay mode, it fails when some interactions were not used,
+// because the provider no longer sends requests it used to send.
+func (r *Recorder) Stop() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	switch r.mode {
+	case ModeRecord:
+		data, err := json.MarshalIndent(r.cassette, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(r.path), 0o750); err != nil {
+			return err
+		}
+		return os.WriteFile(r.path, append(data, '\n'), 0o600)
+	case ModeReplay:
+		var unused []string
+		for _, interaction := range r.cassette.Interactions {
+			if !interaction.used {
+				unused = append(unused, interaction.Request.Method+" "+interaction.Request.URI)
+			}
+		}
+		if len(unused) > 0 {
+			return errors.New("cassette " + r.path + " has unused interactions: " + strings.Join(unused, ", "))
+		}
+	}
+	return nil
+}
+
+func filterHeader(header http.Header) http.Header {
+	filtered := http.Header{}
+	for _, name := range savedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			filtered[name] = values
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+// scrubBody returns JSON body with secrets replaced. Bodies, which are not JSON, are saved as JSON string.
+func scrubBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		parsed = jwtPattern.ReplaceAllString(string(body), scrubbedValue)
+	} else {
+		parsed = scrubValue(parsed)
+	}
+	scrubbed, err := json.Marshal(parsed)
+	if err != nil {
+		return json.RawMessage(`"` + scrubbedValue + `"`)
+	}
+	return scrubbed
+}
+
+// scrubValue replaces values of secret keys in value and JWTs in all strings.
+func scrubValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			headers, isMap := val.(map[string]any)
+			switch {
+			// Credential configurations keep their structure, so the provider can parse them during replay
+			case indykite.IsCredentialConfigKey(key):
+				v[key] = scrubConfig(val)
+			case isSecretKey(key):
+				v[key] = scrubbedValue
+			case isMap && strings.EqualFold(key, headersKey):
+				scrubHeaders(headers)
+			default:
+				v[key] = scrubValue(val)
+			}
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = scrubValue(val)
+		}
+	case string:
+		return jwtPattern.ReplaceAllString(v, scrubbedValue)
+	}
+	return value
+}
+
+// scrubConfig scrubs credential configuration, which is a JSON object, or JSON object encoded in a string.
+// Anything else is replaced.
+func scrubConfig(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return scrubValue(v)
+	case string:
+		var nested map[string]any
+		if json.Unmarshal([]byte(v), &nested) == nil {
+			if encoded, err := json.Marshal(scrubValue(nested)); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+	return scrubbedValue
+}
+
+// scrubHeaders replaces whole values of headers with credentials, like {"values":["Bearer ..."]}.
+func scrubHeaders(headers map[string]any) {
+	for name, val := range headers {
+		if indykite.IsSensitiveHeader(name) {
+			headers[name] = scrubbedValue
+			continue
+		}
+		headers[name] = scrubValue(val)
+	}
+}
+
+// isSecretKey reports whether key holds a secret. Page tokens are needed to replay listing.
+func isSecretKey(key string) bool {
+	switch strings.ReplaceAll(strings.ToLower(key), "_", "") {
+	case "pagetoken", "nextpagetoken":
+		return false
+	}
+	return indykite.IsSensitiveKey(key)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykitetest_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		cassette string
+		ctx      = context.Background()
+	)
+
+	BeforeEach(func() {
+		cassette = filepath.Join(GinkgoT().TempDir(), "testdata", "cassette.json")
+	})
+
+	// scenario creates service account credential and lists credentials page by page.
+	scenario := func(client *indykite.RestClient, orgID string) (string, string) {
+		var account indykite.ServiceAccountResponse
+		Expect(client.Post(ctx, "/service-accounts", indykite.CreateServiceAccountRequest{
+			OrganizationID: orgID, Name: "robot", Role: "all_editor",
+		}, &account)).To(Succeed())
+		var cred indykite.ServiceAccountCredentialResponse
+		for range 3 {
+			Expect(client.Post(ctx, "/service-account-credentials", indykite.CreateServiceAccountCredentialRequest{
+				ServiceAccountID: account.ID,
+			}, &cred)).To(Succeed())
+		}
+		var page indykite.ListResponse[indykite.ServiceAccountCredentialResponse]
+		Expect(client.Get(ctx, "/service-account-credentials?organization_id="+orgID, &page)).To(Succeed())
+		Expect(client.Get(ctx, "/service-account-credentials?organization_id="+orgID+
+			"&page_token="+page.NextPageToken, &page)).To(Succeed())
+		return cred.ID, cred.ServiceAccountConfig
+	}
+
+	It("records scrubbed interactions and replays them without network", func() {
+		server := indykitetest.NewServer()
+		server.PageSize = 2
+		recorder, err := indykitetest.NewRecorder(cassette, indykitetest.ModeRecord, server.HTTPClient().Transport)
+		Expect(err).To(Succeed())
+		orgID := server.OrganizationID()
+		credID, config := scenario(indykite.NewTestRestClient(server.URL(), &http.Client{Transport: recorder}), orgID)
+		Expect(config).To(ContainSubstring(`"d":`))
+		Expect(recorder.Stop()).To(Succeed())
+		server.Close()
+
+		data, err := os.ReadFile(cassette)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(`"uri": "/configs/v1/service-accounts"`))
+		Expect(string(data)).To(ContainSubstring(`page_token=2`))
+		Expect(string(data)).To(ContainSubstring(`\"privateKeyJWK\":\"***SCRUBBED***\"`))
+		Expect(string(data)).NotTo(ContainSubstring(`\"d\":`))
+		Expect(string(data)).NotTo(ContainSubstring("test-token"))
+
+		replayer, err := indykitetest.NewRecorder(cassette, indykitetest.ModeReplay, nil)
+		Expect(err).To(Succeed())
+		client, err := replayer.RestClient(ctx)
+		Expect(err).To(Succeed())
+		replayedID, replayedConfig := scenario(client, orgID)
+		Expect(replayedID).To(Equal(credID))
+		Expect(replayedConfig).To(ContainSubstring(`"privateKeyJWK":"***SCRUBBED***"`))
+		Expect(replayer.Stop()).To(Succeed())
+
+		err = client.Get(ctx, "/service-accounts/"+credID, nil)
+		Expect(err).To(MatchError(ContainSubstring("has no unused interaction for GET")))
+	})
+
+	It("scrubs credentials in headers sent by the API", func() {
+		server := indykitetest.NewServer()
+		DeferCleanup(server.Close)
+		projectID, err := server.Seed(indykitetest.Projects, map[string]any{
+			"organization_id": server.OrganizationID(), "name": "project",
+		})
+		Expect(err).To(Succeed())
+		recorder, err := indykitetest.NewRecorder(cassette, indykitetest.ModeRecord, server.HTTPClient().Transport)
+		Expect(err).To(Succeed())
+		client := indykite.NewTestRestClient(server.URL(), &http.Client{Transport: recorder})
+		Expect(client.Post(ctx, "/external-data-resolvers", indykite.CreateExternalDataResolverRequest{
+			ProjectID: projectID, Name: "resolver", URL: "https://example.com", Method: "GET",
+			Headers: map[string]any{
+				"Authorization": map[string]any{"values": []string{"Bearer very-secret-bearer"}},
+				"X-API-Key":     map[string]any{"values": []string{"very-secret-api-key"}},
+				"Accept":        map[string]any{"values": []string{"application/json"}},
+			},
+		}, nil)).To(Succeed())
+		Expect(recorder.Stop()).To(Succeed())
+
+		data, err := os.ReadFile(cassette)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(`"Authorization": "***SCRUBBED***"`))
+		Expect(string(data)).To(ContainSubstring(`"X-API-Key": "***SCRUBBED***"`))
+		Expect(string(data)).To(ContainSubstring(`"application/json"`))
+		Expect(string(data)).NotTo(ContainSubstring("very-secret"))
+	})
+
+	It("reports interactions, which were not replayed", func() {
+		server := indykitetest.NewServer()
+		DeferCleanup(server.Close)
+		recorder, err := indykitetest.NewRecorder(cassette, indykitetest.ModeRecord, server.HTTPClient().Transport)
+		Expect(err).To(Succeed())
+		client := indykite.NewTestRestClient(server.URL(), &http.Client{Transport: recorder})
+		Expect(client.Get(ctx, "/organizations/current", nil)).To(Succeed())
+		Expect(client.Get(ctx, "/projects?organization_id="+server.OrganizationID(), nil)).To(Succeed())
+		Expect(recorder.Stop()).To(Succeed())
+
+		replayer, err := indykitetest.NewRecorder(cassette, indykitetest.ModeReplay, nil)
+		Expect(err).To(Succeed())
+		client, err = replayer.RestClient(ctx)
+		Expect(err).To(Succeed())
+		var org indykite.CustomerResponse
+		Expect(client.Get(ctx, "/organizations/current", &org)).To(Succeed())
+		Expect(org.ID).To(Equal(server.OrganizationID()))
+		Expect(replayer.Stop()).To(MatchError(ContainSubstring("unused interactions: GET /configs/v1/projects")))
+	})
+
+	It("reads mode from environment", func() {
+		GinkgoT().Setenv(indykitetest.CassetteModeEnv, "")
+		Expect(indykitetest.ModeFromEnv()).To(Equal(indykitetest.ModeLive))
+		GinkgoT().Setenv(indykitetest.CassetteModeEnv, "replay")
+		Expect(indykitetest.ModeFromEnv()).To(Equal(indykitetest.ModeReplay))
+		GinkgoT().Setenv(indykitetest.CassetteModeEnv, "rewind")
+		_, err := indykitetest.ModeFromEnv()
+		Expect(err).To(MatchError(ContainSubstring("invalid " + indykitetest.CassetteModeEnv)))
+	})
+})
@@ -0,0 +1,452 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package indykitetest serves an in-memory, stateful fake of IndyKite Config API,
+// so the provider can run full plan, apply, import and destroy cycles without a live backend.
+package indykitetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BasePath is the path prefix of all Config API endpoints.
+const BasePath = "/configs/v1"
+
+// Fault makes matching requests fail with given status instead of being served.
+type Fault struct {
+	// Method of the request, empty matches any method.
+	Method string
+	// Path prefix of the request relative to BasePath, like "/projects". Empty matches any path.
+	Path string
+	// Body of the response, defaults to the Config API error envelope with the status text.
+	Body string
+	// Status code of the response.
+	Status int
+	// Times is how many matching requests fail, zero means all of them.
+	Times int
+}
+
+// Server is an in-memory Config API. All objects live only as long as the server.
+type Server struct {
+	httpServer     *httptest.Server
+	organizationID string
+	objects        map[string]map[string]map[string]any
+	order          map[string][]string
+	faults         []*Fault
+	// PageSize limits number of entries in every list response, zero returns all entries at once.
+	PageSize int
+	mutex    sync.Mutex
+	sequence int
+}
+
+// NewServer starts a new fake Config API with a single organization. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		objects: map[string]map[string]map[string]any{},
+		order:   map[string][]string{},
+	}
+	s.organizationID = s.newGID("organizations")
+	s.httpServer = httptest.NewServer(http.StripPrefix(BasePath, http.HandlerFunc(s.serveHTTP)))
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// URL returns the base URL of Config API, including BasePath.
+func (s *Server) URL() string {
+	return s.httpServer.URL + BasePath
+}
+
+// HTTPClient returns HTTP client configured to talk to the server.
+func (s *Server) HTTPClient() *http.Client {
+	return s.httpServer.Client()
+}
+
+// OrganizationID returns GID of the organization, which owns credentials used to call the server.
+func (s *Server) OrganizationID() string {
+	return s.organizationID
+}
+
+// InjectFault adds fault checked before every request. Faults are evaluated in the order of injection.
+func (s *Server) InjectFault(fault Fault) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// Seed creates object in collection directly, as if it was created outside of Terraform.
+// It returns the GID of the new object.
+func (s *Server) Seed(collectionName string, object map[string]any) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	created, _, err := s.create(collectionName, maps.Clone(object))
+	if err != nil {
+		return "", err
+	}
+	return created["id"].(string), nil
+}
+
+// Object returns a copy of the stored object.
+func (s *Server) Object(collectionName, id string) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	obj, ok := s.objects[collectionName][id]
+	return maps.Clone(obj), ok
+}
+
+// Objects returns copies of all objects of the collection in the order of creation.
+func (s *Server) Objects(collectionName string) []map[string]any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := make([]map[string]any, 0, len(s.order[collectionName]))
+	for _, id := range s.order[collectionName] {
+		result = append(result, maps.Clone(s.objects[collectionName][id]))
+	}
+	return result
+}
+
+// Modify changes stored object outside of Terraform, which also changes its etag.
+// It is useful to simulate drift and concurrent modifications.
+func (s *Server) Modify(collectionName, id string, fields map[string]any) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	obj, ok := s.objects[collectionName][id]
+	if !ok {
+		return fmt.Errorf("%s %s not found", collectionName, id)
+	}
+	maps.Copy(obj, fields)
+	s.touch(obj)
+	return nil
+}
+
+// apiError is written in the shape of the Config API error envelope.
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	status  int
+}
+
+func newAPIError(status int, format string, args ...any) *apiError {
+	code := strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+	return &apiError{status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if fault := s.matchFault(r); fault != nil {
+		body := fault.Body
+		if body == "" {
+			raw, _ := json.Marshal(newAPIError(fault.Status, "injected fault: %s", http.StatusText(fault.Status)))
+			body = string(raw)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fault.Status)
+		_, _ = w.Write([]byte(body))
+		return
+	}
+
+	resp, err := s.route(r)
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(err.status)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if (fault.Method != "" && fault.Method != r.Method) || !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) route(r *http.Request) (any, *apiError) {
+	if r.URL.Path == "/organizations/current" && r.Method == http.MethodGet {
+		return map[string]any{"id": s.organizationID, "name": "indykitetest", "display_name": "IndyKite Test"}, nil
+	}
+
+	collectionName, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if _, ok := collections[collectionName]; !ok || strings.Contains(id, "/") {
+		return nil, newAPIError(http.StatusNotFound, "unknown path %s", r.URL.Path)
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		return s.list(collectionName, r)
+	case id == "" && r.Method == http.MethodPost:
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, newAPIError(http.StatusBadRequest, "invalid request body: %v", err)
+		}
+		created, responseOnly, err := s.create(collectionName, body)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(created, responseOnly)
+		return created, nil
+	case id == "":
+		return nil, newAPIError(http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+
+	obj, err := s.lookup(collectionName, id, r)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return obj, nil
+	case http.MethodPut:
+		if err = checkEtag(obj, r); err != nil {
+			return nil, err
+		}
+		var body map[string]any
+		if decodeErr := json.NewDecoder(r.Body).Decode(&body); decodeErr != nil {
+			return nil, newAPIError(http.StatusBadRequest, "invalid request body: %v", decodeErr)
+		}
+		for key, value := range body {
+			if !immutableKey(collections[collectionName], key) {
+				obj[key] = value
+			}
+		}
+		s.touch(obj)
+		return obj, nil
+	case http.MethodDelete:
+		if err = checkEtag(obj, r); err != nil {
+			return nil, err
+		}
+		if child := s.findChild(collectionName, obj["id"].(string)); child != "" {
+			return nil, newAPIError(http.StatusBadRequest, "%s %s still has children, delete %s first",
+				collectionName, obj["id"], child)
+		}
+		delete(s.objects[collectionName], obj["id"].(string))
+		s.order[collectionName] = slices.DeleteFunc(s.order[collectionName], func(v string) bool {
+			return v == obj["id"]
+		})
+		return map[string]any{}, nil
+	}
+	return nil, newAPIError(http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+}
+
+// create stores new object, which must reference existing parent, and returns it together with
+// fields returned only in the create response.
+func (s *Server) create(collectionName string, obj map[string]any) (map[string]any, map[string]any, *apiError) {
+	col, ok := collections[collectionName]
+	if !ok {
+		return nil, nil, newAPIError(http.StatusNotFound, "unknown collection %s", collectionName)
+	}
+	parentID, _ := obj[col.parentKey].(string)
+	if col.parent == "" {
+		if parentID != s.organizationID {
+			return nil, nil, newAPIError(http.StatusBadRequest,
+				"%s: organization %q not found", col.parentKey, parentID)
+		}
+	} else {
+		parent, found := s.objects[col.parent][parentID]
+		if !found {
+			return nil, nil, newAPIError(http.StatusBadRequest,
+				"%s: %s %q not found", col.parentKey, col.parent, parentID)
+		}
+		for _, key := range scopeKeys {
+			if value, has := parent[key]; has {
+				obj[key] = value
+			}
+		}
+		obj[col.parentKey] = parentID
+	}
+
+	if name, has := obj["name"].(string); has {
+		for _, other := range s.objects[collectionName] {
+			if other["name"] == name && other[col.parentKey] == parentID {
+				return nil, nil, newAPIError(http.StatusConflict,
+					"%s with name %q already exists", collectionName, name)
+			}
+		}
+	}
+
+	id := s.newGID(collectionName)
+	obj["id"] = id
+	obj["create_time"] = time.Now().UTC()
+	var responseOnly map[string]any
+	if col.create != nil {
+		var err error
+		if responseOnly, err = col.create(obj); err != nil {
+			return nil, nil, newAPIError(http.StatusInternalServerError, "%v", err)
+		}
+	}
+	s.touch(obj)
+
+	if s.objects[collectionName] == nil {
+		s.objects[collectionName] = map[string]map[string]any{}
+	}
+	s.objects[collectionName][id] = obj
+	s.order[collectionName] = append(s.order[collectionName], id)
+	return obj, responseOnly, nil
+}
+
+// lookup finds object by GID, or by name within the scope given in the query string.
+func (s *Server) lookup(collectionName, idOrName string, r *http.Request) (map[string]any, *apiError) {
+	if strings.HasPrefix(idOrName, "gid:") {
+		if obj, ok := s.objects[collectionName][idOrName]; ok {
+			return obj, nil
+		}
+		return nil, newAPIError(http.StatusNotFound, "%s %s not found", collectionName, idOrName)
+	}
+	query := r.URL.Query()
+	if !hasScope(query) {
+		return nil, newAPIError(http.StatusBadRequest, "lookup of %s by name requires scope parameter", collectionName)
+	}
+	for _, id := range s.order[collectionName] {
+		obj := s.objects[collectionName][id]
+		if obj["name"] == idOrName && inScope(obj, query) {
+			return obj, nil
+		}
+	}
+	return nil, newAPIError(http.StatusNotFound, "%s %q not found", collectionName, idOrName)
+}
+
+func (s *Server) list(collectionName string, r *http.Request) (any, *apiError) {
+	query := r.URL.Query()
+	if !hasScope(query) {
+		return nil, newAPIError(http.StatusBadRequest, "listing %s requires scope parameter", collectionName)
+	}
+	search := query.Get("search")
+	data := []map[string]any{}
+	for _, id := range s.order[collectionName] {
+		obj := s.objects[collectionName][id]
+		name, _ := obj["name"].(string)
+		if inScope(obj, query) && strings.Contains(name, search) {
+			data = append(data, obj)
+		}
+	}
+
+	offset := 0
+	if token := query.Get("page_token"); token != "" {
+		var err error
+		if offset, err = strconv.Atoi(token); err != nil || offset < 0 || offset > len(data) {
+			return nil, newAPIError(http.StatusBadRequest, "invalid page_token %q", token)
+		}
+	}
+	resp := map[string]any{}
+	data = data[offset:]
+	if s.PageSize > 0 && len(data) > s.PageSize {
+		data = data[:s.PageSize]
+		resp["next_page_token"] = strconv.Itoa(offset + s.PageSize)
+	}
+	resp["data"] = data
+	return resp, nil
+}
+
+// findChild returns ID of any object, which has the object with given ID as parent.
+func (s *Server) findChild(collectionName, id string) string {
+	for name, col := range collections {
+		if col.parent != collectionName {
+			continue
+		}
+		for _, childID := range s.order[name] {
+			if s.objects[name][childID][col.parentKey] == id {
+				return childID
+			}
+		}
+	}
+	return ""
+}
+
+// touch marks the object as modified with a new etag.
+func (s *Server) touch(obj map[string]any) {
+	s.sequence++
+	obj["update_time"] = time.Now().UTC()
+	obj["etag"] = "etag-" + strconv.Itoa(s.sequence)
+}
+
+// newGID returns a new valid GID, which are sequential to keep tests deterministic.
+func (s *Server) newGID(kind string) string {
+	s.sequence++
+	raw := fmt.Sprintf("indykitetest/%s/%08d", kind, s.sequence)
+	return "gid:" + base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func checkEtag(obj map[string]any, r *http.Request) *apiError {
+	etag := r.Header.Get("If-Match")
+	if etag != "" && etag != obj["etag"] {
+		return newAPIError(http.StatusPreconditionFailed, "etag %q does not match current %q", etag, obj["etag"])
+	}
+	return nil
+}
+
+// immutableKey reports whether the field cannot be changed by update request.
+func immutableKey(col collection, key string) bool {
+	switch key {
+	case "id", "name", "create_time", "update_time", "etag", col.parentKey:
+		return true
+	}
+	return slices.Contains(scopeKeys, key)
+}
+
+// hasScope reports whether query contains any parameter limiting the scope of the request.
+func hasScope(query map[string][]string) bool {
+	for key := range query {
+		if key == locationParam || slices.Contains(scopeKeys, key) || strings.HasSuffix(key, "_id") {
+			return true
+		}
+	}
+	return false
+}
+
+// inScope checks all scope parameters of the query. Location matches any ancestor of the object.
+func inScope(obj map[string]any, query map[string][]string) bool {
+	for key, values := range query {
+		value := values[0]
+		switch {
+		case key == locationParam:
+			if !slices.ContainsFunc(scopeKeys, func(scopeKey string) bool { return obj[scopeKey] == value }) {
+				return false
+			}
+		case strings.HasSuffix(key, "_id"):
+			if obj[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
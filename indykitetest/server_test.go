@@ -0,0 +1,198 @@
+// Copyright (c) 2026 IndyKite
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indykitetest_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fake Config API", func() {
+	var (
+		server *indykitetest.Server
+		client *indykite.RestClient
+		ctx    = context.Background()
+	)
+
+	BeforeEach(func() {
+		server = indykitetest.NewServer()
+		DeferCleanup(server.Close)
+		client = server.Client()
+	})
+
+	createProject := func(name string) indykite.ApplicationSpaceResponse {
+		var resp indykite.ApplicationSpaceResponse
+		Expect(client.Post(ctx, "/projects", indykite.CreateApplicationSpaceRequest{
+			CustomerID: server.OrganizationID(), Name: name, Region: "europe-west1",
+		}, &resp)).To(Succeed())
+		return resp
+	}
+
+	It("creates objects with GIDs and scope inherited from parent", func() {
+		project := createProject("my-project")
+		Expect(project.ID).To(MatchRegexp(`^gid:[A-Za-z0-9_-]{22,}$`))
+		Expect(project.IKGStatus).To(Equal("APP_SPACE_IKG_STATUS_STATUS_ACTIVE"))
+		Expect(project.Etag).NotTo(BeEmpty())
+
+		var app indykite.ApplicationResponse
+		Expect(client.Post(ctx, "/applications", indykite.CreateApplicationRequest{
+			ProjectID: project.ID, Name: "my-app",
+		}, &app)).To(Succeed())
+		Expect(app.CustomerID).To(Equal(server.OrganizationID()))
+		Expect(app.AppSpaceID).To(Equal(project.ID))
+
+		var agent indykite.ApplicationAgentResponse
+		Expect(client.Post(ctx, "/application-agents", indykite.CreateApplicationAgentRequest{
+			ApplicationID: app.ID, Name: "my-agent",
+		}, &agent)).To(Succeed())
+		Expect(agent.AppSpaceID).To(Equal(project.ID))
+
+		var cred indykite.ApplicationAgentCredentialResponse
+		Expect(client.Post(ctx, "/application-agent-credentials", indykite.CreateApplicationAgentCredentialRequest{
+			ApplicationAgentID: agent.ID,
+		}, &cred)).To(Succeed())
+		Expect(cred.Kid).NotTo(BeEmpty())
+		Expect(string(cred.AgentConfig)).To(ContainSubstring(`"privateKeyJWK"`))
+		// Configuration is returned only on creation
+		Expect(client.Get(ctx, "/application-agent-credentials/"+cred.ID, &cred)).To(Succeed())
+		Expect(cred.ApplicationID).To(Equal(app.ID))
+
+		err := client.Post(ctx, "/applications", indykite.CreateApplicationRequest{
+			ProjectID: project.ID, Name: "my-app",
+		}, &app)
+		Expect(err).To(MatchError(ContainSubstring("HTTP 409")))
+		err = client.Post(ctx, "/applications", indykite.CreateApplicationRequest{
+			ProjectID: "gid:AAAAAmluZHlraURlgAABDwAAAAA", Name: "other",
+		}, &app)
+		Expect(err).To(MatchError(ContainSubstring("project_id")))
+	})
+
+	It("looks up objects by name within scope and lists them page by page", func() {
+		project := createProject("my-project")
+		other := createProject("other-project")
+		for _, name := range []string{"a", "b", "c"} {
+			_, err := server.Seed(indykitetest.AuthorizationPolicies, map[string]any{
+				"project_id": project.ID, "name": name, "policy": "{}",
+			})
+			Expect(err).To(Succeed())
+		}
+		_, err := server.Seed(indykitetest.AuthorizationPolicies, map[string]any{"project_id": other.ID, "name": "a"})
+		Expect(err).To(Succeed())
+
+		var policy indykite.AuthorizationPolicyResponse
+		Expect(client.Get(ctx, "/authorization-policies/b?project_id="+project.ID, &policy)).To(Succeed())
+		Expect(policy.Name).To(Equal("b"))
+		Expect(client.Get(ctx, "/authorization-policies/a?location="+other.ID, &policy)).To(Succeed())
+		Expect(policy.AppSpaceID).To(Equal(other.ID))
+		Expect(indykite.IsNotFoundError(
+			client.Get(ctx, "/authorization-policies/b?location="+other.ID, &policy))).To(BeTrue())
+
+		server.PageSize = 2
+		var page indykite.ListResponse[indykite.AuthorizationPolicyResponse]
+		Expect(client.Get(ctx, "/authorization-policies?project_id="+project.ID, &page)).To(Succeed())
+		Expect(page.Data).To(HaveLen(2))
+		Expect(page.NextPageToken).NotTo(BeEmpty())
+		var lastPage indykite.ListResponse[indykite.AuthorizationPolicyResponse]
+		Expect(client.Get(ctx, "/authorization-policies?project_id="+project.ID+
+			"&page_token="+page.NextPageToken, &lastPage)).To(Succeed())
+		Expect(lastPage.Data).To(ConsistOf(HaveField("Name", "c")))
+		Expect(lastPage.NextPageToken).To(BeEmpty())
+	})
+
+	It("rejects stale etags and deletion of objects with children", func() {
+		project := createProject("my-project")
+		displayName := "New name"
+		var updated indykite.ApplicationSpaceResponse
+		Expect(client.PutIfMatch(ctx, "/projects/"+project.ID, project.Etag,
+			indykite.UpdateApplicationSpaceRequest{DisplayName: &displayName}, &updated)).To(Succeed())
+		Expect(updated.DisplayName).To(Equal(displayName))
+		Expect(updated.Name).To(Equal("my-project"))
+		Expect(updated.Etag).NotTo(Equal(project.Etag))
+
+		err := client.PutIfMatch(ctx, "/projects/"+project.ID, project.Etag,
+			indykite.UpdateApplicationSpaceRequest{DisplayName: &displayName}, &updated)
+		Expect(indykite.IsConflictError(err)).To(BeTrue())
+
+		appID, err := server.Seed(indykitetest.Applications, map[string]any{"project_id": project.ID, "name": "app"})
+		Expect(err).To(Succeed())
+		Expect(client.DeleteIfMatch(ctx, "/projects/"+project.ID, updated.Etag)).To(MatchError(ContainSubstring(appID)))
+
+		Expect(client.Delete(ctx, "/applications/"+appID)).To(Succeed())
+		Expect(client.DeleteIfMatch(ctx, "/projects/"+project.ID, updated.Etag)).To(Succeed())
+		Expect(indykite.IsNotFoundError(client.Get(ctx, "/projects/"+project.ID, &updated))).To(BeTrue())
+		Expect(server.Objects(indykitetest.Projects)).To(BeEmpty())
+	})
+
+	It("injects faults", func() {
+		server.InjectFault(indykitetest.Fault{Method: http.MethodPost, Path: "/projects", Status: 503, Times: 1})
+		err := client.Post(ctx, "/projects", indykite.CreateApplicationSpaceRequest{
+			CustomerID: server.OrganizationID(), Name: "my-project",
+		}, nil)
+		Expect(indykite.IsServiceError(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("injected fault")))
+		Expect(server.Objects(indykitetest.Projects)).To(BeEmpty())
+
+		createProject("my-project")
+		Expect(server.Objects(indykitetest.Projects)).To(HaveLen(1))
+	})
+
+	It("runs provider resources through create, import, update and destroy", func() {
+		provider := server.Provider()
+		Expect(provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))).To(BeEmpty())
+		project := createProject("my-project")
+
+		res := provider.ResourcesMap["indykite_application"]
+		data := schema.TestResourceDataRaw(GinkgoT(), res.Schema, map[string]any{
+			"app_space_id": project.ID, "name": "my-app", "display_name": "My App",
+			"deletion_protection": false,
+		})
+		Expect(res.CreateContext(ctx, data, provider.Meta())).To(BeEmpty())
+		Expect(data.Get("customer_id")).To(Equal(server.OrganizationID()))
+		stored, ok := server.Object(indykitetest.Applications, data.Id())
+		Expect(ok).To(BeTrue())
+		Expect(stored).To(HaveKeyWithValue("display_name", "My App"))
+
+		imported := res.TestResourceData()
+		imported.SetId("my-project/my-app")
+		states, err := res.Importer.StateContext(ctx, imported, provider.Meta())
+		Expect(err).To(Succeed())
+		Expect(states).To(HaveLen(1))
+		Expect(states[0].Id()).To(Equal(data.Id()))
+
+		// Drift made outside of Terraform is detected by read
+		Expect(server.Modify(indykitetest.Applications, data.Id(), map[string]any{"description": "changed"})).
+			To(Succeed())
+		Expect(res.ReadContext(ctx, data, provider.Meta())).To(BeEmpty())
+		Expect(data.Get("description")).To(Equal("changed"))
+
+		Expect(data.Set("display_name", "Renamed")).To(Succeed())
+		Expect(res.UpdateContext(ctx, data, provider.Meta())).To(BeEmpty())
+		stored, _ = server.Object(indykitetest.Applications, data.Id())
+		Expect(stored).To(HaveKeyWithValue("display_name", "Renamed"))
+		Expect(stored).To(HaveKeyWithValue("etag", data.Get("etag")))
+
+		Expect(res.DeleteContext(ctx, data, provider.Meta())).To(BeEmpty())
+		Expect(server.Objects(indykitetest.Applications)).To(BeEmpty())
+	})
+})
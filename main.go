@@ -16,32 +16,102 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io"
+	"log"
 	"os"
 
-	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate(os.Args[2:]))
+	}
+
 	var debugMode bool
 	// https://www.terraform.io/docs/extend/debugging.html#enabling-debugging-in-a-provider
 	flag.BoolVar(&debugMode, "debug", false,
 		"set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
-	plugin.Serve(&plugin.ServeOpts{
-		GRPCProviderFunc: func() tfprotov5.ProviderServer {
-			return schema.NewGRPCProviderServer(indykite.Provider())
-		},
-		NoLogOutputOverride: acceptanceTesting(),
-		Debug:               debugMode,
-	})
+
+	ctx := context.Background()
+	// Resources migrated to terraform-plugin-framework are served together with SDK ones over protocol v6
+	providerServer, err := indykite.ProviderServerFactory(ctx, indykite.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+	if acceptanceTesting() {
+		serveOpts = append(serveOpts, tf6server.WithoutLogStderrOverride())
+	}
+	if err = tf6server.Serve("registry.terraform.io/indykite/indykite", providerServer, serveOpts...); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func acceptanceTesting() bool {
 	_, ok := os.LookupEnv("TF_TEST_ENV_ACCEPTANCE_TESTING")
 	return ok
 }
+
+// generate writes Terraform configuration with import blocks of existing IndyKite configuration.
+// Credentials are read the same way as by the provider, from flags or environment variables.
+func generate(args []string) int {
+	var (
+		clientCfg indykite.RestClientConfig
+		opts      indykite.GenerateOptions
+		outFile   string
+	)
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(),
+			"Usage: terraform-provider-indykite generate [flags]\n\n"+
+				"Writes resource and import blocks of existing IndyKite configuration.\n"+
+				"Sensitive attributes are replaced by variables.")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.OrganizationID, "organization", "",
+		"GID of organization to walk, defaults to the organization of the service account")
+	flags.StringVar(&opts.ProjectID, "project", "", "GID of single Application Space to walk")
+	flags.StringVar(&outFile, "out", "", "output file, defaults to standard output")
+	flags.StringVar(&clientCfg.CredentialsFile, "credentials-file", "",
+		"path to service account credentials file, defaults to INDYKITE_SERVICE_ACCOUNT_CREDENTIALS[_FILE]")
+	flags.StringVar(&clientCfg.BaseURL, "base-url", "", "base URL of IndyKite API")
+	flags.StringVar(&clientCfg.Region, "region", "", "region of IndyKite API, eu or us")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx := context.Background()
+	client, err := indykite.NewRestClientWithConfig(ctx, &clientCfg)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+
+	var out io.Writer = os.Stdout
+	if outFile != "" {
+		file, err := os.Create(outFile) // #nosec G304 -- output path is given by the user
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+			return 1
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	if err = indykite.GenerateConfig(ctx, client, opts, out); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
@@ -19,14 +19,21 @@ package terraform_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/indykite/terraform-provider-indykite/indykite"
+	"github.com/indykite/terraform-provider-indykite/indykitetest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
+// resourcesFixture keeps IDs of resources from the recorded tfstate, so replay needs no tfstate.
+const resourcesFixture = "testdata/resources.json"
+
 var (
 	client   *indykite.RestClient
 	myResult = make(map[string]string)
@@ -54,10 +61,33 @@ type Attribute struct {
 
 var _ = Describe("Terraform", func() {
 	BeforeEach(func() {
-		var err error
-		client, err = indykite.NewRestClient(context.Background())
+		// Set INDYKITE_TEST_CASSETTE_MODE=record to refresh fixtures, or replay to run without credentials
+		mode, err := indykitetest.ModeFromEnv()
+		Expect(err).To(Succeed())
+		cassette := filepath.Join("testdata", CurrentSpecReport().LeafNodeText+".json")
+		if mode == indykitetest.ModeReplay {
+			for _, fixture := range []string{resourcesFixture, cassette} {
+				if _, statErr := os.Stat(fixture); errors.Is(statErr, fs.ErrNotExist) {
+					Skip("replay fixture " + fixture + " is missing, " +
+						"run with INDYKITE_TEST_CASSETTE_MODE=record against real API to create it")
+				}
+			}
+		}
+		recorder, err := indykitetest.NewRecorder(cassette, mode, nil)
+		Expect(err).To(Succeed())
+		DeferCleanup(func() {
+			Expect(recorder.Stop()).To(Succeed())
+		})
+		client, err = recorder.RestClient(context.Background())
 		Expect(err).To(Succeed())
 
+		if mode == indykitetest.ModeReplay {
+			jsonData, readErr := os.ReadFile(resourcesFixture)
+			Expect(readErr).To(Succeed())
+			Expect(json.Unmarshal(jsonData, &myResult)).To(Succeed())
+			return
+		}
+
 		jsonData, err := os.ReadFile("../provider/terraform.tfstate")
 		Expect(err).To(Succeed())
 		Expect(jsonData).NotTo(BeNil())
@@ -70,6 +100,13 @@ var _ = Describe("Terraform", func() {
 			myResult[getid.Name] = getid.Instances[0].Attributes.ID
 		}
 		Expect(myResult).NotTo(BeNil())
+
+		if mode == indykitetest.ModeRecord {
+			jsonData, err = json.MarshalIndent(myResult, "", "  ")
+			Expect(err).To(Succeed())
+			Expect(os.MkdirAll(filepath.Dir(resourcesFixture), 0o750)).To(Succeed())
+			Expect(os.WriteFile(resourcesFixture, append(jsonData, '\n'), 0o600)).To(Succeed())
+		}
 	})
 
 	It("ReadAppSpace", func() {